@@ -0,0 +1,246 @@
+// Package cassette records HTTP interactions to a file and replays them, so
+// tests can run against recorded responses without an API key or network.
+// Responses can be recorded from the real Riot API or from a fake server
+// such as riottest. The X-Riot-Token header is never written to a cassette.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode selects whether a Recorder replays or records.
+type Mode int
+
+const (
+	// Replay serves responses from the cassette and fails requests that
+	// were not recorded. No request reaches the network.
+	Replay Mode = iota
+	// Record sends requests on and appends them to the cassette, replacing
+	// a cassette recorded earlier.
+	Record
+)
+
+func (m Mode) String() string {
+	switch m {
+	case Replay:
+		return "replay"
+	case Record:
+		return "record"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// ErrNotRecorded is returned in Replay mode for requests missing from the
+// cassette.
+var ErrNotRecorded = errors.New("request not recorded in cassette")
+
+// scrubbedHeaders are left out of recorded requests.
+var scrubbedHeaders = []string{"X-Riot-Token", "Authorization"}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// Response holds a recorded response. JSON bodies are stored as JSON so
+// that cassettes stay readable; other bodies are stored in Body.
+type Response struct {
+	StatusCode int             `json:"statusCode"`
+	Header     http.Header     `json:"header,omitempty"`
+	JSON       json.RawMessage `json:"json,omitempty"`
+	Body       string          `json:"body,omitempty"`
+}
+
+func (r Response) body() []byte {
+	if r.JSON != nil {
+		return r.JSON
+	}
+	return []byte(r.Body)
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording to or replaying from one
+// cassette file.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	mu           sync.Mutex
+	loaded       bool
+	loadErr      error
+	interactions []Interaction
+	// played counts how often each request was replayed, by method and URL.
+	played map[string]int
+}
+
+// New returns a Recorder for the cassette at path. In Record mode requests
+// are sent with next, or http.DefaultTransport if next is nil.
+func New(path string, mode Mode, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{path: path, mode: mode, next: next, played: make(map[string]int)}
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip replays or records req. Identical requests are replayed in the
+// order they were recorded; once they are used up, the last one repeats.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == Record {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.loaded {
+		r.loaded = true
+		r.loadErr = r.load()
+	}
+	if r.loadErr != nil {
+		return nil, r.loadErr
+	}
+
+	key := req.Method + " " + req.URL.String()
+	var matches []Interaction
+	for _, interaction := range r.interactions {
+		if interaction.Request.Method+" "+interaction.Request.URL == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s in %s", ErrNotRecorded, key, r.path)
+	}
+	played := r.played[key]
+	r.played[key]++
+	return matches[min(played, len(matches)-1)].Response.response(req), nil
+}
+
+func (r *Recorder) load() error {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("load cassette: %w", err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("load cassette %s: %w", r.path, err)
+	}
+	r.interactions = file.Interactions
+	return nil
+}
+
+func (resp Response) response(req *http.Request) *http.Response {
+	body := resp.body()
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := req.Header.Clone()
+	for _, name := range scrubbedHeaders {
+		header.Del(name)
+	}
+	recorded := Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone()}
+	if json.Valid(body) && len(body) > 0 {
+		var compact bytes.Buffer
+		json.Compact(&compact, body)
+		recorded.JSON = compact.Bytes()
+	} else {
+		recorded.Body = string(body)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request:  Request{Method: req.Method, URL: req.URL.String(), Header: header},
+		Response: recorded,
+	})
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// save writes all interactions recorded so far, one per line, replacing the
+// file atomically so an interrupted recording leaves a valid cassette.
+func (r *Recorder) save() error {
+	var data bytes.Buffer
+	data.WriteString("{\"interactions\": [\n")
+	for i, interaction := range r.interactions {
+		line, err := json.Marshal(interaction)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			data.WriteString(",\n")
+		}
+		data.Write(line)
+	}
+	data.WriteString("\n]}\n")
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ".cassette-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), fs.FileMode(0o644)); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
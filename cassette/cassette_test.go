@@ -0,0 +1,73 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func get(t *testing.T, rt http.RoundTripper, url string) (int, string, error) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	req.Header.Set("X-Riot-Token", "RGAPI-secret")
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body), nil
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "not found")
+			return
+		}
+		io.WriteString(w, `{"call": `+string(rune('0'+calls))+`}`)
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder := New(path, Record, nil)
+	for _, url := range []string{srv.URL + "/a", srv.URL + "/a", srv.URL + "/missing"} {
+		if _, _, err := get(t, recorder, url); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "RGAPI-secret") {
+		t.Error("cassette contains the API key")
+	}
+
+	srv.Close()
+	player := New(path, Replay, nil)
+	for _, want := range []string{`{"call":1}`, `{"call":2}`, `{"call":2}`} {
+		status, body, err := get(t, player, srv.URL+"/a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status != http.StatusOK || body != want {
+			t.Errorf("got %d %s, want %s", status, body, want)
+		}
+	}
+	if status, body, _ := get(t, player, srv.URL+"/missing"); status != http.StatusNotFound || body != "not found" {
+		t.Errorf("got %d %q for recorded 404", status, body)
+	}
+	if _, _, err := get(t, player, srv.URL+"/b"); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("expected ErrNotRecorded, got %v", err)
+	}
+}
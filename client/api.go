@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// AccountAPI, SummonerAPI, MatchAPI and LeagueAPI group the typed core
+// methods of Client by Riot API, so code using the SDK can depend on the
+// part it needs and be tested against a fake such as lolmock.Client. They
+// leave out the *Raw variants, the iterators such as MatchIDs and the
+// methods generated by lolgen, such as the champion mastery ones; code
+// needing those can declare its own interface or take a *Client.
+type AccountAPI interface {
+	GetAccountByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Account, error)
+	GetAccountByRiotID(ctx context.Context, gameName string, tagLine string, region types.Region) (*types.Account, error)
+}
+
+type SummonerAPI interface {
+	GetSummonerByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Summoner, error)
+}
+
+type MatchAPI interface {
+	GetMatchHistoryByPUUID(ctx context.Context, puuid types.PUUID, region types.Region, count int) ([]string, error)
+	GetMatch(ctx context.Context, matchID string, region types.Region) (*types.Match, error)
+	GetMatchTimeline(ctx context.Context, matchID string, region types.Region) (*types.MatchTimeline, error)
+}
+
+type LeagueAPI interface {
+	GetChallengerLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetGrandMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetLeagueEntries(ctx context.Context, queue string, tier string, division string, region types.Region) ([]types.LeagueEntry, error)
+	GetLeagueEntriesByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) ([]types.LeagueEntry, error)
+}
+
+// API is implemented by Client and covers all of the interfaces above, and
+// like them only the typed core methods.
+type API interface {
+	AccountAPI
+	SummonerAPI
+	MatchAPI
+	LeagueAPI
+}
+
+var _ API = (*Client)(nil)
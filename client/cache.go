@@ -0,0 +1,237 @@
+package client
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// NoExpiry marks an endpoint whose responses never change once fetched.
+const NoExpiry time.Duration = -1
+
+// DefaultCacheTTLs is used for endpoints missing from Config.CacheTTLs.
+// Endpoints without a TTL are not cached.
+var DefaultCacheTTLs = map[string]time.Duration{
+	EndpointAccountByPUUID:       time.Hour,
+	EndpointAccountByRiotID:      time.Hour,
+	EndpointSummonerByPUUID:      time.Hour,
+	EndpointMatchIDsByPUUID:      time.Minute,
+	EndpointMatch:                NoExpiry,
+	EndpointMatchTimeline:        NoExpiry,
+	EndpointChallengerLeague:     5 * time.Minute,
+	EndpointGrandmasterLeague:    5 * time.Minute,
+	EndpointMasterLeague:         5 * time.Minute,
+	EndpointLeagueEntries:        5 * time.Minute,
+	EndpointLeagueEntriesByPUUID: 5 * time.Minute,
+}
+
+// CacheEntry is a stored API response. Expired entries are kept so that they
+// can be revalidated with If-None-Match when Riot returned an ETag.
+type CacheEntry struct {
+	Body       []byte      `json:"body"`
+	Header     http.Header `json:"header"`
+	StatusCode int         `json:"statusCode"`
+	FetchedAt  time.Time   `json:"fetchedAt"`
+	ExpiresAt  time.Time   `json:"expiresAt"`
+	ETag       string      `json:"etag,omitempty"`
+	KeyName    string      `json:"keyName,omitempty"`
+}
+
+func (e CacheEntry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Cache stores responses keyed by request URL, prefixed with the key name
+// for calls pinned with WithAPIKey.
+type Cache interface {
+	Get(key string) (CacheEntry, bool, error)
+	Set(key string, entry CacheEntry) error
+}
+
+// MemoryCache is an in-memory Cache evicting the least recently used entry
+// once it holds more than its capacity.
+type MemoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryCache) Get(key string) (CacheEntry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return CacheEntry{}, false, nil
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheItem).entry, true, nil
+}
+
+func (m *MemoryCache) Set(key string, entry CacheEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.items[key]; ok {
+		elem.Value.(*memoryCacheItem).entry = entry
+		m.order.MoveToFront(elem)
+		return nil
+	}
+
+	m.items[key] = m.order.PushFront(&memoryCacheItem{key: key, entry: entry})
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheItem).key)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// FileCache is a Cache storing one JSON file per entry in a directory.
+type FileCache struct {
+	dir string
+}
+
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (f *FileCache) Get(key string) (CacheEntry, bool, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return CacheEntry{}, false, nil
+	}
+	if err != nil {
+		return CacheEntry{}, false, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return CacheEntry{}, false, fmt.Errorf("failed to parse cache entry: %w", err)
+	}
+	return entry, true, nil
+}
+
+func (f *FileCache) Set(key string, entry CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) cacheTTL(endpoint string) (time.Duration, bool) {
+	if ttl, ok := c.config.CacheTTLs[endpoint]; ok {
+		return ttl, ttl != 0
+	}
+	ttl, ok := DefaultCacheTTLs[endpoint]
+	return ttl, ok
+}
+
+func (c *Client) cacheable(endpoint string) bool {
+	if c.config.Cache == nil {
+		return false
+	}
+	_, ok := c.cacheTTL(endpoint)
+	return ok
+}
+
+func (c *Client) cacheGet(endpoint string, key string) (CacheEntry, bool) {
+	entry, ok, err := c.config.Cache.Get(key)
+	if err != nil {
+		c.logger.Warn("cache lookup failed", "endpoint", endpoint, "key", key, "error", err)
+		return CacheEntry{}, false
+	}
+	return entry, ok
+}
+
+func (c *Client) cacheSet(endpoint string, key string, raw *rawResponse) {
+	if c.config.Cache == nil {
+		return
+	}
+	ttl, ok := c.cacheTTL(endpoint)
+	if !ok {
+		return
+	}
+
+	entry := CacheEntry{
+		Body:       raw.body,
+		Header:     raw.header,
+		StatusCode: raw.statusCode,
+		FetchedAt:  raw.fetchedAt,
+		ETag:       raw.header.Get("ETag"),
+		KeyName:    raw.keyName,
+	}
+	if ttl != NoExpiry {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+	if err := c.config.Cache.Set(key, entry); err != nil {
+		c.logger.Warn("cache store failed", "endpoint", endpoint, "key", key, "error", err)
+	}
+}
+
+func (e CacheEntry) raw() *rawResponse {
+	return &rawResponse{
+		body:       e.Body,
+		header:     e.Header,
+		statusCode: e.StatusCode,
+		fetchedAt:  e.FetchedAt,
+		fromCache:  true,
+		keyName:    e.KeyName,
+	}
+}
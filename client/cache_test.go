@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func newStubClient(t *testing.T, config Config, rt roundTripFunc) *Client {
+	if config.APIKey == "" && len(config.APIKeys) == 0 {
+		config.APIKey = "RGAPI-test"
+	}
+	if config.RequestsPerMin == 0 {
+		config.RequestsPerMin = 6000
+	}
+	c := NewClient(config, nil)
+	c.httpClient.Transport = rt
+	return c
+}
+
+func stubResponse(status int, body string, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", CacheEntry{Body: []byte("a")})
+	cache.Set("b", CacheEntry{Body: []byte("b")})
+	cache.Get("a")
+	cache.Set("c", CacheEntry{Body: []byte("c")})
+
+	if _, ok, _ := cache.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+}
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	cache, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := CacheEntry{
+		Body:       []byte(`{"puuid":"p"}`),
+		Header:     http.Header{"Etag": {`"v1"`}},
+		StatusCode: http.StatusOK,
+		FetchedAt:  time.Now().UTC().Truncate(time.Second),
+		ETag:       `"v1"`,
+	}
+	if err := cache.Set("https://example/a", entry); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := cache.Get("https://example/a")
+	if err != nil || !ok {
+		t.Fatalf("Get: ok=%v err=%v", ok, err)
+	}
+	if string(got.Body) != string(entry.Body) || got.ETag != entry.ETag || !got.FetchedAt.Equal(entry.FetchedAt) {
+		t.Errorf("got %+v, want %+v", got, entry)
+	}
+	if _, ok, _ := cache.Get("https://example/b"); ok {
+		t.Error("unexpected hit for missing key")
+	}
+}
+
+func TestClientCachesImmutableMatches(t *testing.T) {
+	var calls atomic.Int32
+	c := newStubClient(t, Config{Cache: NewMemoryCache(10)}, func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		resp, err := c.GetMatchRaw(ctx, "EUW1_1", types.EUW1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Value.Metadata.MatchID != "EUW1_1" {
+			t.Fatalf("unexpected match %q", resp.Value.Metadata.MatchID)
+		}
+		if resp.FromCache != (i > 0) {
+			t.Errorf("call %d: FromCache = %v", i, resp.FromCache)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 HTTP call, got %d", n)
+	}
+}
+
+func TestClientRevalidatesWithETag(t *testing.T) {
+	var calls atomic.Int32
+	c := newStubClient(t, Config{
+		Cache:     NewMemoryCache(10),
+		CacheTTLs: map[string]time.Duration{EndpointChallengerLeague: time.Nanosecond},
+	}, func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) > 1 {
+			if got := req.Header.Get("If-None-Match"); got != `"v1"` {
+				t.Errorf("If-None-Match = %q", got)
+			}
+			return stubResponse(http.StatusNotModified, "", nil), nil
+		}
+		return stubResponse(http.StatusOK, `{"tier":"CHALLENGER"}`, http.Header{"Etag": {`"v1"`}}), nil
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		league, err := c.GetChallengerLeague(ctx, "RANKED_SOLO_5x5", types.EUW1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if league.Tier != "CHALLENGER" {
+			t.Fatalf("unexpected tier %q", league.Tier)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 HTTP calls, got %d", n)
+	}
+}
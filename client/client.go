@@ -2,276 +2,521 @@ package client
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"sync"
+	"net/url"
+	"strconv"
 	"time"
 
-	"github.com/rs/zerolog"
-	"golang.org/x/time/rate"
-
+	"github.com/travior/lol-sdk/cassette"
 	"github.com/travior/lol-sdk/types"
 )
 
 type Client struct {
-	httpClient       *http.Client
-	logger           *zerolog.Logger
-	rateLimiters     map[string]*rate.Limiter
-	rateLimiterMutex sync.RWMutex
-	config           Config
+	httpClient  *http.Client
+	doer        Doer
+	observer    observers
+	logger      *slog.Logger
+	rateLimiter RateLimiter
+	// methodLimiters enforce Config.MethodRateLimits by rate-limit key.
+	methodLimiters map[string]RateLimiter
+	keys           *keyPool
+	config         Config
+	flights        flightGroup
+	stats          clientStats
 }
 
 type Config struct {
-	APIKey         string
+	APIKey string
+	// APIKeys adds further keys to the pool. Each call uses the key with the
+	// most rate-limit headroom unless it is pinned with WithAPIKey.
+	APIKeys []APIKey
+	// KeyQuarantine is how long a key rejected with 401 is skipped. The
+	// last key that is not quarantined is never skipped.
+	KeyQuarantine time.Duration
+
+	// RequestsPerMin is the rate of the default rate limiter. Zero or less
+	// disables it, leaving only Riot's 429 responses to slow calls down.
 	RequestsPerMin int
 	BurstSize      int
+	DecodeMode     DecodeMode
+
+	// HighPriorityReserve is the fraction of RequestsPerMin that only
+	// PriorityHigh calls may use. See WithPriority.
+	HighPriorityReserve float64
+
+	// RateLimiter replaces the default MemoryRateLimiter built from the
+	// fields above, e.g. to share limits between processes.
+	RateLimiter RateLimiter
+
+	// MethodRateLimits limits calls per minute by EndpointDef.RateLimitKey,
+	// on top of the application limit, mirroring Riot's method rate limits.
+	// Like the application limit they apply per API key and routing value.
+	MethodRateLimits map[string]int
+
+	// Cache, when set, stores responses of endpoints with a TTL. CacheTTLs
+	// overrides DefaultCacheTTLs per endpoint; a zero TTL disables caching.
+	Cache     Cache
+	CacheTTLs map[string]time.Duration
+
+	// DisableDeduplication stops concurrent calls for the same URL from
+	// sharing a single HTTP request.
+	DisableDeduplication bool
+
+	// IDKeyCheck controls calls with encrypted IDs tagged for a key other
+	// than the one the call would use. Tagged IDs pin unpinned calls to
+	// their key when it is in the pool.
+	IDKeyCheck IDCheck
+
+	// MaxRetries is how often a call is retried after a 429 or 5xx response.
+	// Retries honour Retry-After and otherwise back off exponentially from
+	// RetryBackoff (default 500ms).
+	MaxRetries   int
+	RetryBackoff time.Duration
+
+	// HTTPClient sends the requests, by default an http.Client with a 30
+	// second timeout. riottest.Server.HTTPClient points it at a fake API.
+	HTTPClient *http.Client
+
+	// Cassette, when set, is the path of a cassette file requests are
+	// recorded to or replayed from, as selected by CassetteMode. See the
+	// cassette package.
+	Cassette     string
+	CassetteMode cassette.Mode
+
+	// Middleware wraps every outgoing request, the first entry outermost.
+	Middleware []RequestMiddleware
+
+	// Observers receive events about every API call, e.g. from the lolotel
+	// package.
+	Observers []Observer
+
+	// LogLevel is the minimum level the client logs at, on top of the
+	// logger's own (default slog.LevelWarn). Every call logs one line with
+	// its outcome: failures at Warn, 404s at Info and successes at Debug.
+	LogLevel slog.Leveler
 }
 
-func NewClient(config Config, logger *zerolog.Logger) *Client {
-	return &Client{
-		httpClient: &http.Client{
+// NewClient creates a client. logger may be nil, in which case nothing is
+// logged; use the slogzerolog package to log to a zerolog.Logger.
+func NewClient(config Config, logger *slog.Logger) *Client {
+	rateLimiter := config.RateLimiter
+	if rateLimiter == nil {
+		rateLimiter = NewMemoryRateLimiter(config.RequestsPerMin, config.BurstSize, config.HighPriorityReserve)
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Timeout: 30 * time.Second,
-		},
-		logger:       logger,
-		rateLimiters: make(map[string]*rate.Limiter),
-		config:       config,
+		}
+	}
+	if config.Cassette != "" {
+		recorded := *httpClient
+		recorded.Transport = cassette.New(config.Cassette, config.CassetteMode, httpClient.Transport)
+		httpClient = &recorded
+	}
+
+	methodLimiters := make(map[string]RateLimiter, len(config.MethodRateLimits))
+	for rateLimitKey, requestsPerMin := range config.MethodRateLimits {
+		methodLimiters[rateLimitKey] = NewMemoryRateLimiter(requestsPerMin, 0, 0)
+	}
+
+	return &Client{
+		httpClient:     httpClient,
+		methodLimiters: methodLimiters,
+		doer:           chainMiddleware(httpClient, config.Middleware),
+		observer:       observers(config.Observers),
+		logger:         newLogger(logger, config.LogLevel),
+		rateLimiter:    rateLimiter,
+		keys:           newKeyPool(config),
+		config:         config,
+	}
+}
+
+// apiCall is one call of a Riot API method.
+type apiCall struct {
+	endpoint     string
+	method       string
+	url          string
+	routingValue string
+	rateLimitKey string
+	pathParams   map[string]string
+}
+
+func (call apiCall) info(attempt int, keyName string) RequestInfo {
+	return RequestInfo{
+		Endpoint:     call.endpoint,
+		RoutingValue: call.routingValue,
+		PathParams:   call.pathParams,
+		KeyName:      keyName,
+		Attempt:      attempt,
+	}
+}
+
+// makeRequest performs call and hands a successful response to decode. The
+// call is logged and reported to observers only after decode returns, so a
+// response that fails to decode counts as a failed call.
+func (c *Client) makeRequest(ctx context.Context, call apiCall, decode func(*rawResponse) error) error {
+	c.stats.requests.Add(1)
+
+	info := call.info(0, "")
+	start := time.Now()
+	ctx = c.observer.CallStart(ctx, info)
+	res, shared := c.dedupRequest(ctx, call)
+	raw, err := res.raw, res.err
+	if err == nil {
+		err = decode(raw)
 	}
+
+	result := CallResult{Err: err, Duration: time.Since(start), Shared: shared, Attempts: res.attempts}
+	var apiErr *APIError
+	if raw != nil {
+		result.StatusCode = raw.statusCode
+		result.FromCache = raw.fromCache
+	} else if errors.As(err, &apiErr) {
+		result.StatusCode = apiErr.StatusCode
+	}
+	c.observer.CallEnd(ctx, info, result)
+	c.logCall(ctx, call, result)
+
+	return err
 }
 
-func (c *Client) getRateLimiter(routingValue string) *rate.Limiter {
-	c.rateLimiterMutex.RLock()
-	limiter, exists := c.rateLimiters[routingValue]
-	c.rateLimiterMutex.RUnlock()
+// requestResult is the outcome of the HTTP work for a call, shared by every
+// call that joined its flight.
+type requestResult struct {
+	raw      *rawResponse
+	attempts int
+	err      error
+}
+
+func (c *Client) dedupRequest(ctx context.Context, call apiCall) (requestResult, bool) {
+	// Responses containing encrypted IDs differ between keys, so calls pinned
+	// to a key neither share results with nor are cached for other calls.
+	requestKey := call.url
+	if name, ok := APIKeyFromContext(ctx); ok {
+		requestKey = name + " " + call.url
+	}
 
-	if exists {
-		return limiter
+	if c.config.DisableDeduplication {
+		return c.doRequest(ctx, call, requestKey), false
 	}
 
-	c.rateLimiterMutex.Lock()
-	defer c.rateLimiterMutex.Unlock()
+	// Only calls waiting with the same priority share a flight, so a high
+	// priority call never waits behind a low priority one.
+	flightKey := strconv.Itoa(int(PriorityFromContext(ctx))) + " " + requestKey
+	res, shared := c.flights.do(ctx, flightKey, func(ctx context.Context) requestResult {
+		info := call.info(0, "")
+		ctx = c.observer.FlightStart(ctx, info)
+		res := c.doRequest(ctx, call, requestKey)
+		c.observer.FlightEnd(ctx, info, res.err)
+		return res
+	})
+	if shared {
+		c.stats.collapsed.Add(1)
+	}
+	return res, shared
+}
 
-	if limiter, exists := c.rateLimiters[routingValue]; exists {
-		return limiter
+func (c *Client) doRequest(ctx context.Context, call apiCall, requestKey string) requestResult {
+	var cached CacheEntry
+	var hasCached bool
+	if c.cacheable(call.endpoint) {
+		cached, hasCached = c.cacheGet(call.endpoint, requestKey)
+		fresh := hasCached && !cached.Expired(time.Now())
+		c.observer.CacheLookup(ctx, call.info(0, ""), fresh)
+		if fresh {
+			return requestResult{raw: cached.raw()}
+		}
 	}
-	c.rateLimiters[routingValue] = rate.NewLimiter(rate.Limit(float64(c.config.RequestsPerMin)/60.0), 1)
-	c.logger.Info().Str("routing_value", routingValue).Msg("Created new limiter")
 
-	return c.rateLimiters[routingValue]
+	for attempt := 1; ; attempt++ {
+		raw, err := c.attemptRequest(ctx, call, attempt, cached, hasCached)
+		if err == nil {
+			c.cacheSet(call.endpoint, requestKey, raw)
+			return requestResult{raw: raw, attempts: attempt}
+		}
+
+		var apiErr *APIError
+		if attempt > c.config.MaxRetries || !errors.As(err, &apiErr) || !apiErr.retryable() {
+			return requestResult{attempts: attempt, err: err}
+		}
+
+		delay, ok := apiErr.retryAfter()
+		if !ok {
+			delay = min(c.retryBackoff()<<(attempt-1), maxRetryBackoff)
+		}
+		c.logger.DebugContext(ctx, "retrying request", "endpoint", call.endpoint, "status", apiErr.StatusCode, "attempt", attempt, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return requestResult{attempts: attempt, err: ctx.Err()}
+		case <-timer.C:
+		}
+	}
 }
 
-func (c *Client) makeRequest(ctx context.Context, url string, routingValue string) ([]byte, error) {
-	limiter := c.getRateLimiter(routingValue)
-	if err := limiter.Wait(ctx); err != nil {
-		c.logger.Err(err).Str("routing_value", routingValue).Str("url", url).Msg("rate limiter wait failed")
+func (c *Client) attemptRequest(ctx context.Context, call apiCall, attempt int, cached CacheEntry, hasCached bool) (_ *rawResponse, err error) {
+	routingValue, url := call.routingValue, call.url
+
+	key, err := c.keys.acquire(ctx, routingValue)
+	if err != nil {
+		return nil, err
+	}
+	status := 0
+	var header http.Header
+	defer func() { c.keys.release(key, routingValue, status, header) }()
+
+	info := call.info(attempt, key.Name)
+	waitCtx := c.observer.LimiterWaitStart(ctx, info)
+	waitStart := time.Now()
+	err = c.rateLimiter.Wait(waitCtx, key.Name+":"+routingValue)
+	if limiter, ok := c.methodLimiters[call.rateLimitKey]; ok && err == nil {
+		err = limiter.Wait(waitCtx, key.Name+":"+routingValue)
+	}
+	c.observer.LimiterWaitEnd(waitCtx, info, time.Since(waitStart), err)
+	if err != nil {
 		return nil, fmt.Errorf("rate limiter wait failed: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	reqCtx := withRequestInfo(ctx, info)
+	req, err := http.NewRequestWithContext(reqCtx, call.method, url, nil)
 	if err != nil {
-		c.logger.Err(err).Str("routing_value", routingValue).Str("url", url).Msg("failed to create request")
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-Riot-Token", c.config.APIKey)
+	req.Header.Set("X-Riot-Token", key.Key)
 	req.Header.Set("User-Agent", "lol-sdk/1.0")
+	if hasCached && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
 
-	resp, err := c.httpClient.Do(req)
+	fetchedAt := time.Now()
+	// err is the named result, so observers see the error returned for
+	// every attempt, including the APIError of a retried 429 or 5xx.
+	defer func() {
+		c.observer.AttemptEnd(ctx, info, AttemptResult{
+			StatusCode: status,
+			Header:     header,
+			Err:        err,
+			Duration:   time.Since(fetchedAt),
+		})
+	}()
+	resp, err := c.doer.Do(req)
 	if err != nil {
-		c.logger.Err(err).Str("routing_value", routingValue).Str("url", url).Msg("failed to make request")
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
+	status, header = resp.StatusCode, resp.Header
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		c.logger.Err(err).Str("routing_value", routingValue).Str("url", url).Msg("failed to read response body")
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		raw := cached.raw()
+		raw.fetchedAt = fetchedAt
+		return raw, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		c.logger.Warn().Str("routing_value", routingValue).Str("url", url).Int("status", resp.StatusCode).Msg("Got non OK status code")
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: body, Header: resp.Header}
 	}
 
-	return body, nil
+	return &rawResponse{
+		body:       body,
+		header:     resp.Header,
+		statusCode: resp.StatusCode,
+		fetchedAt:  fetchedAt,
+		keyName:    key.Name,
+	}, nil
 }
 
-func getAccountRouting(region types.Region) string {
-	switch region {
-	case types.BR1, types.LA1, types.LA2, types.OC1, types.NA1:
-		return "americas"
-	case types.KR, types.JP1:
-		return "asia"
-	case types.EUN1, types.EUW1, types.TR1, types.RU:
-		return "europe"
+const (
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 30 * time.Second
+)
+
+func (c *Client) retryBackoff() time.Duration {
+	if c.config.RetryBackoff > 0 {
+		return c.config.RetryBackoff
 	}
-	return "" //switch is exhaustive
+	return defaultRetryBackoff
 }
 
-func (c *Client) GetSummonerByPUUID(ctx context.Context, puuid string, region types.Region) (*types.Summoner, error) {
-	c.logger.Debug().Str("puuid", puuid).Str("region", region.ToString()).Msg("Fetching summoner")
-
-	routingValue := getAccountRouting(region)
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/summoner/v4/summoners/by-puuid/%s", routingValue, puuid)
+func (c *Client) decode(body []byte, v any, kind string, id string) ([]types.DecodeWarning, error) {
+	warnings, err := Decode(body, v, c.config.DecodeMode)
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		decodeErr.Kind = kind
+		decodeErr.ID = id
+	}
+	if len(warnings) > 0 {
+		paths := make([]string, len(warnings))
+		for i, warning := range warnings {
+			paths[i] = warning.Path
+		}
+		c.logger.Warn("zeroed mismatched fields", "kind", kind, "id", id, "paths", paths)
+	}
+	return warnings, err
+}
 
-	body, err := c.makeRequest(ctx, url, routingValue)
+func (c *Client) GetAccountByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Account, error) {
+	resp, err := c.GetAccountByPUUIDRaw(ctx, puuid, region)
 	if err != nil {
-		c.logger.Err(err).Str("puuid", puuid).Str("region", region.ToString()).Msg("Failed to fetch summoner")
 		return nil, err
 	}
+	return &resp.Value, nil
+}
 
-	var summoner types.Summoner
-	if err := json.Unmarshal(body, &summoner); err != nil {
-		c.logger.Err(err).Str("puuid", puuid).Str("region", region.ToString()).Msg("Failed to parse response")
+func (c *Client) GetAccountByPUUIDRaw(ctx context.Context, puuid types.PUUID, region types.Region) (*Response[types.Account], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", puuid.EncryptedID)
+	if err != nil {
 		return nil, err
 	}
-
-	return &summoner, nil
+	return Call(ctx, c, accountByPUUID, region, Params{"puuid": puuid.Value}, nil)
 }
 
-func (c *Client) GetMatchHistoryByPUUID(ctx context.Context, puuid string, region types.Region, count int) ([]string, error) {
-	c.logger.Debug().Str("puuid", puuid).Str("region", region.ToString()).Msg("Fetching match history")
-
-	routingValue := getAccountRouting(region)
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/match/v5/matches/by-puuid/%s/ids?start=0&count=%d", routingValue, puuid, count)
-
-	body, err := c.makeRequest(ctx, url, routingValue)
+// GetAccountByRiotID looks up an account by its Riot ID, e.g. "Faker#KR1"
+// is gameName "Faker" and tagLine "KR1".
+func (c *Client) GetAccountByRiotID(ctx context.Context, gameName string, tagLine string, region types.Region) (*types.Account, error) {
+	resp, err := c.GetAccountByRiotIDRaw(ctx, gameName, tagLine, region)
 	if err != nil {
-		c.logger.Err(err).Str("puuid", puuid).Str("region", region.ToString()).Msg("Failed to fetch match history")
 		return nil, err
 	}
+	return &resp.Value, nil
+}
 
-	var matches []string
-	if err := json.Unmarshal(body, &matches); err != nil {
-		c.logger.Err(err).Str("puuid", puuid).Str("region", region.ToString()).Msg("Failed to parse response")
+func (c *Client) GetAccountByRiotIDRaw(ctx context.Context, gameName string, tagLine string, region types.Region) (*Response[types.Account], error) {
+	return Call(ctx, c, accountByRiotID, region, Params{"gameName": gameName, "tagLine": tagLine}, nil)
+}
+
+func (c *Client) GetSummonerByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Summoner, error) {
+	resp, err := c.GetSummonerByPUUIDRaw(ctx, puuid, region)
+	if err != nil {
 		return nil, err
 	}
-
-	c.logger.Debug().Str("puuid", puuid).Str("region", region.ToString()).Int("match_count", len(matches)).Interface("matches", matches).Msg("Match history fetched")
-	return matches, err
-
+	return &resp.Value, nil
 }
 
-func (c *Client) GetMatch(ctx context.Context, matchID string, region types.Region) (*types.Match, error) {
-	c.logger.Debug().Str("matchID", matchID).Str("region", region.ToString()).Msg("Fetching match")
-
-	routingValue := getAccountRouting(region)
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/match/v5/matches/%s", routingValue, matchID)
-
-	body, err := c.makeRequest(ctx, url, routingValue)
+func (c *Client) GetSummonerByPUUIDRaw(ctx context.Context, puuid types.PUUID, region types.Region) (*Response[types.Summoner], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", puuid.EncryptedID)
 	if err != nil {
-		c.logger.Err(err).Str("matchID", matchID).Str("region", region.ToString()).Msg("Failed to fetch match")
 		return nil, err
 	}
+	return Call(ctx, c, summonerByPUUID, region, Params{"encryptedPUUID": puuid.Value}, nil)
+}
 
-	var match types.Match
-	if err := json.Unmarshal(body, &match); err != nil {
-		c.logger.Err(err).Str("matchID", matchID).Str("region", region.ToString()).Msg("Failed to parse match")
+func (c *Client) GetMatchHistoryByPUUID(ctx context.Context, puuid types.PUUID, region types.Region, count int) ([]string, error) {
+	resp, err := c.GetMatchHistoryByPUUIDRaw(ctx, puuid, region, count)
+	if err != nil {
 		return nil, err
 	}
-
-	return &match, nil
+	return resp.Value, nil
 }
 
-func (c *Client) GetMatchTimeline(ctx context.Context, matchID string, region types.Region) (*types.MatchTimeline, error) {
-	c.logger.Debug().Str("matchID", matchID).Str("region", region.ToString()).Msg("Fetching match timeline")
-
-	routingValue := getAccountRouting(region)
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/match/v5/matches/%s/timeline", routingValue, matchID)
-
-	body, err := c.makeRequest(ctx, url, routingValue)
+func (c *Client) GetMatchHistoryByPUUIDRaw(ctx context.Context, puuid types.PUUID, region types.Region, count int) (*Response[[]string], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", puuid.EncryptedID)
 	if err != nil {
-		c.logger.Err(err).Str("matchID", matchID).Str("region", region.ToString()).Msg("Failed to fetch match timeline")
 		return nil, err
 	}
+	query := url.Values{"start": {"0"}, "count": {strconv.Itoa(count)}}
+	return Call(ctx, c, matchIDsByPUUID, region, Params{"puuid": puuid.Value}, query)
+}
 
-	var timeline types.MatchTimeline
-	if err := json.Unmarshal(body, &timeline); err != nil {
-		c.logger.Err(err).Str("matchID", matchID).Str("region", region.ToString()).Msg("Failed to parse match timeline")
+func (c *Client) GetMatch(ctx context.Context, matchID string, region types.Region) (*types.Match, error) {
+	resp, err := c.GetMatchRaw(ctx, matchID, region)
+	if err != nil {
 		return nil, err
 	}
-
-	return &timeline, nil
+	return &resp.Value, nil
 }
 
-func (c *Client) GetChallengerLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
-	c.logger.Debug().Str("queue", queue).Str("region", region.ToString()).Msg("Fetching challengers")
-
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/league/v4/challengerleagues/by-queue/%s", region.ToString(), queue)
+func (c *Client) GetMatchRaw(ctx context.Context, matchID string, region types.Region) (*Response[types.Match], error) {
+	return Call(ctx, c, match, region, Params{"matchId": matchID}, nil)
+}
 
-	body, err := c.makeRequest(ctx, url, region.ToString())
+func (c *Client) GetMatchTimeline(ctx context.Context, matchID string, region types.Region) (*types.MatchTimeline, error) {
+	resp, err := c.GetMatchTimelineRaw(ctx, matchID, region)
 	if err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to get challenger league")
 		return nil, err
 	}
+	return &resp.Value, nil
+}
+
+func (c *Client) GetMatchTimelineRaw(ctx context.Context, matchID string, region types.Region) (*Response[types.MatchTimeline], error) {
+	return Call(ctx, c, matchTimeline, region, Params{"matchId": matchID}, nil)
+}
 
-	var league types.LeagueList
-	if err := json.Unmarshal(body, &league); err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to parse challenger league response")
+func (c *Client) GetChallengerLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
+	resp, err := c.GetChallengerLeagueRaw(ctx, queue, region)
+	if err != nil {
 		return nil, err
 	}
+	return &resp.Value, nil
+}
 
-	return &league, nil
+func (c *Client) GetChallengerLeagueRaw(ctx context.Context, queue string, region types.Region) (*Response[types.LeagueList], error) {
+	return Call(ctx, c, challengerLeague, region, Params{"queue": queue}, nil)
 }
 
 func (c *Client) GetGrandMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
-	c.logger.Debug().Str("queue", queue).Str("region", region.ToString()).Msg("Fetching grandmasters")
-
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/league/v4/grandmasterleagues/by-queue/%s", region.ToString(), queue)
-
-	body, err := c.makeRequest(ctx, url, region.ToString())
+	resp, err := c.GetGrandMasterLeagueRaw(ctx, queue, region)
 	if err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to get grandmaster league")
-		return nil, err
-	}
-
-	var league types.LeagueList
-	if err := json.Unmarshal(body, &league); err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to parse grandmaster league response")
 		return nil, err
 	}
+	return &resp.Value, nil
+}
 
-	return &league, nil
+func (c *Client) GetGrandMasterLeagueRaw(ctx context.Context, queue string, region types.Region) (*Response[types.LeagueList], error) {
+	return Call(ctx, c, grandmasterLeague, region, Params{"queue": queue}, nil)
 }
 
 func (c *Client) GetMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
-	c.logger.Debug().Str("queue", queue).Str("region", region.ToString()).Msg("Fetching masters")
-
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/league/v4/masterleagues/by-queue/%s", region.ToString(), queue)
-
-	body, err := c.makeRequest(ctx, url, region.ToString())
+	resp, err := c.GetMasterLeagueRaw(ctx, queue, region)
 	if err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to get master league")
-		return nil, err
-	}
-
-	var league types.LeagueList
-	if err := json.Unmarshal(body, &league); err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("region", region.ToString()).Msg("Failed to parse master league response")
 		return nil, err
 	}
+	return &resp.Value, nil
+}
 
-	return &league, nil
+func (c *Client) GetMasterLeagueRaw(ctx context.Context, queue string, region types.Region) (*Response[types.LeagueList], error) {
+	return Call(ctx, c, masterLeague, region, Params{"queue": queue}, nil)
 }
 
 func (c *Client) GetLeagueEntries(ctx context.Context, queue string, tier string, division string, region types.Region) ([]types.LeagueEntry, error) {
-	c.logger.Debug().Str("queue", queue).Str("tier", tier).Str("division", division).Str("region", region.ToString()).Msg("Fetching league entries")
+	resp, err := c.GetLeagueEntriesRaw(ctx, queue, tier, division, region)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
 
-	url := fmt.Sprintf("https://%s.api.riotgames.com/lol/league/v4/entries/%s/%s/%s", region.ToString(), queue, tier, division)
+func (c *Client) GetLeagueEntriesRaw(ctx context.Context, queue string, tier string, division string, region types.Region) (*Response[[]types.LeagueEntry], error) {
+	return Call(ctx, c, leagueEntries, region, Params{"queue": queue, "tier": tier, "division": division}, nil)
+}
 
-	body, err := c.makeRequest(ctx, url, region.ToString())
+// GetLeagueEntriesByPUUID returns the ranked entries of a player, one per
+// queue they are ranked in.
+func (c *Client) GetLeagueEntriesByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) ([]types.LeagueEntry, error) {
+	resp, err := c.GetLeagueEntriesByPUUIDRaw(ctx, puuid, region)
 	if err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("tier", tier).Str("division", division).Str("region", region.ToString()).Msg("Failed to get league entries")
 		return nil, err
 	}
+	return resp.Value, nil
+}
 
-	var entries []types.LeagueEntry
-	if err := json.Unmarshal(body, &entries); err != nil {
-		c.logger.Err(err).Str("queue", queue).Str("tier", tier).Str("division", division).Str("region", region.ToString()).Msg("Failed to parse league entries response")
+func (c *Client) GetLeagueEntriesByPUUIDRaw(ctx context.Context, puuid types.PUUID, region types.Region) (*Response[[]types.LeagueEntry], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", puuid.EncryptedID)
+	if err != nil {
 		return nil, err
 	}
-
-	return entries, nil
+	return Call(ctx, c, leagueEntriesByPUUID, region, Params{"encryptedPUUID": puuid.Value}, nil)
 }
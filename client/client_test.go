@@ -2,33 +2,99 @@ package client
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
+
+	"github.com/travior/lol-sdk/cassette"
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/slogzerolog"
 	"github.com/travior/lol-sdk/types"
 )
 
-func setupClient(t *testing.T) *Client {
-	apiKey := os.Getenv("API_KEY")
-	if apiKey == "" {
-		t.Skip("No API Key set")
-	}
+var recordSynthetic = flag.Bool("record-synthetic", false, "regenerate the synthetic cassettes in testdata/synthetic from the riottest fake server")
 
+// setupSyntheticClient returns a client replaying the synthetic cassette of
+// the test, or recording it against a seeded riottest server with
+// -record-synthetic. The cassettes hold riottest fixture data, not real Riot
+// responses; see live_test.go for tests against Riot.
+func setupSyntheticClient(t *testing.T) *Client {
 	logger := zerolog.New(zerolog.NewTestWriter(t)).
 		With().
 		Timestamp().
 		Str("test", t.Name()).
-		Logger().
-		Level(zerolog.WarnLevel)
-
-	return NewClient(Config{
-		APIKey:         apiKey,
-		RequestsPerMin: 60,
-		BurstSize:      1,
-	},
-		&logger)
+		Logger()
+
+	config := Config{
+		APIKey:       "RGAPI-synthetic",
+		Cassette:     filepath.Join("testdata", "synthetic", t.Name()+".json"),
+		CassetteMode: cassette.Replay,
+	}
+	if *recordSynthetic {
+		srv := riottest.NewServer()
+		t.Cleanup(srv.Close)
+		seedRegions(t, srv)
+		config.HTTPClient = srv.HTTPClient()
+		config.CassetteMode = cassette.Record
+	}
+	return NewClient(config, slogzerolog.New(logger))
+}
+
+// seedRegions copies the EUW1 fixtures of srv to every region in regions,
+// so each region serves the same leagues and a match on its own cluster.
+func seedRegions(t *testing.T, srv *riottest.Server) {
+	t.Helper()
+	ctx := context.Background()
+	c := NewClient(Config{APIKey: "RGAPI-synthetic", HTTPClient: srv.HTTPClient()}, nil)
+	match, err := riottest.FixtureMatch(riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeline, err := riottest.FixtureTimeline(riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := c.GetLeagueEntries(ctx, "RANKED_SOLO_5x5", "DIAMOND", "I", riottest.FixtureRegion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var leagues []*types.LeagueList
+	for _, get := range []func(context.Context, string, types.Region) (*types.LeagueList, error){
+		c.GetChallengerLeague, c.GetGrandMasterLeague, c.GetMasterLeague,
+	} {
+		league, err := get(ctx, "RANKED_SOLO_5x5", riottest.FixtureRegion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		leagues = append(leagues, league)
+	}
+
+	for _, region := range regions {
+		if region == riottest.FixtureRegion {
+			continue
+		}
+		for _, league := range leagues {
+			if err := srv.SetLeague(region, *league); err != nil {
+				t.Fatal(err)
+			}
+		}
+		srv.AddLeagueEntries(region, entries...)
+
+		id := region.ToString() + "_7000000001"
+		m, tl := match, timeline
+		m.Metadata.MatchID, m.Info.PlatformID = id, region.ToString()
+		tl.Metadata.MatchID = id
+		if err := srv.AddMatch(m); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.AddTimeline(tl); err != nil {
+			t.Fatal(err)
+		}
+	}
 }
 
 var regions = []types.Region{
@@ -45,8 +111,24 @@ var regions = []types.Region{
 	types.NA1,
 }
 
+// checkLeague checks a league decoded from the riottest league fixtures.
+func checkLeague(t *testing.T, league *types.LeagueList, tier string, entries int) {
+	t.Helper()
+	if league.Tier != tier || league.Queue != "RANKED_SOLO_5x5" || !strings.HasPrefix(league.LeagueID, "riottest-league-") {
+		t.Errorf("league = %s %s %q, want %s RANKED_SOLO_5x5", league.Tier, league.Queue, league.LeagueID, tier)
+	}
+	if len(league.Entries) != entries {
+		t.Fatalf("got %d entries, want %d", len(league.Entries), entries)
+	}
+	for _, entry := range league.Entries {
+		if !strings.HasPrefix(entry.PUUID.Value, "riottest-puuid-") || entry.Rank != "I" || entry.LeaguePoints <= 0 || entry.Wins <= 0 {
+			t.Errorf("unexpected entry %+v", entry)
+		}
+	}
+}
+
 func TestGetChallenger(t *testing.T) {
-	client := setupClient(t)
+	client := setupSyntheticClient(t)
 
 	for _, region := range regions {
 		t.Run(
@@ -57,13 +139,16 @@ func TestGetChallenger(t *testing.T) {
 				if err != nil {
 					t.Fatalf("API call failed: %v", err)
 				}
-				t.Logf("Fetched %d players", len(league.Entries))
+				checkLeague(t, league, "CHALLENGER", 3)
+				if first := league.Entries[0]; first.PUUID.Value != riottest.FixturePUUID || first.LeaguePoints != 1500 || !first.HotStreak {
+					t.Errorf("first entry = %+v", first)
+				}
 			})
 	}
 }
 
 func TestGetGrandmaster(t *testing.T) {
-	client := setupClient(t)
+	client := setupSyntheticClient(t)
 
 	for _, region := range regions {
 		t.Run(
@@ -74,13 +159,13 @@ func TestGetGrandmaster(t *testing.T) {
 				if err != nil {
 					t.Fatalf("API call failed: %v", err)
 				}
-				t.Logf("Fetched %d players", len(league.Entries))
+				checkLeague(t, league, "GRANDMASTER", 2)
 			})
 	}
 }
 
 func TestGetMaster(t *testing.T) {
-	client := setupClient(t)
+	client := setupSyntheticClient(t)
 
 	for _, region := range regions {
 		t.Run(
@@ -91,13 +176,13 @@ func TestGetMaster(t *testing.T) {
 				if err != nil {
 					t.Fatalf("API call failed: %v", err)
 				}
-				t.Logf("Fetched %d players", len(league.Entries))
+				checkLeague(t, league, "MASTER", 2)
 			})
 	}
 }
 
 func TestGetDiamondI(t *testing.T) {
-	client := setupClient(t)
+	client := setupSyntheticClient(t)
 
 	for _, region := range regions {
 		t.Run(
@@ -108,13 +193,20 @@ func TestGetDiamondI(t *testing.T) {
 				if err != nil {
 					t.Fatalf("API call failed: %v", err)
 				}
-				t.Logf("Fetched %d players", len(entries))
+				if len(entries) != 3 {
+					t.Fatalf("got %d entries, want 3", len(entries))
+				}
+				for _, entry := range entries {
+					if entry.Tier != "DIAMOND" || entry.Rank != "I" || entry.QueueType != "RANKED_SOLO_5x5" || entry.PUUID.IsZero() {
+						t.Errorf("unexpected entry %+v", entry)
+					}
+				}
 			})
 	}
 }
 
 func TestMatchHistoryAndData(t *testing.T) {
-	client := setupClient(t)
+	client := setupSyntheticClient(t)
 
 	for _, region := range regions {
 		t.Run(
@@ -122,49 +214,47 @@ func TestMatchHistoryAndData(t *testing.T) {
 			func(t *testing.T) {
 				ctx := context.Background()
 
-				// Get challenger players first
 				league, err := client.GetChallengerLeague(ctx, "RANKED_SOLO_5x5", region)
 				if err != nil {
 					t.Fatalf("Failed to get challenger league: %v", err)
 				}
-
 				if len(league.Entries) == 0 {
-					t.Skip("No challenger players found")
+					t.Fatal("no challenger players")
 				}
-
-				// Get first player's PUUID
 				playerPUUID := league.Entries[0].PUUID
-				if playerPUUID == "" {
-					t.Skip("Player PUUID not available")
-				}
 
-				// Get match history
 				matches, err := client.GetMatchHistoryByPUUID(ctx, playerPUUID, region, 5)
 				if err != nil {
 					t.Fatalf("Failed to get match history: %v", err)
 				}
-
 				if len(matches) == 0 {
-					t.Skip("No matches found")
+					t.Fatal("empty match history")
+				}
+				if !strings.HasSuffix(matches[0], "_7000000001") {
+					t.Errorf("newest match = %s", matches[0])
 				}
 
-				t.Logf("Fetched %d matches", len(matches))
-
-				// Get first match details
 				match, err := client.GetMatch(ctx, matches[0], region)
 				if err != nil {
 					t.Fatalf("Failed to get match: %v", err)
 				}
+				if match.Metadata.MatchID != matches[0] || match.Info.GameDuration != 1805 || match.Info.QueueID != 420 {
+					t.Errorf("match %s: duration %d, queue %d", match.Metadata.MatchID, match.Info.GameDuration, match.Info.QueueID)
+				}
+				if len(match.Info.Participants) != 10 || match.Info.Participants[0].PUUID.Value != playerPUUID.Value {
+					t.Errorf("got %d participants, first %v", len(match.Info.Participants), match.Info.Participants[0].PUUID)
+				}
+				if patch := match.Info.Patch().String(); patch != "14.19" {
+					t.Errorf("patch = %s, want 14.19", patch)
+				}
 
-				t.Logf("Match ID: %s, Duration: %d seconds", match.Metadata.MatchID, match.Info.GameDuration)
-
-				// Get match timeline
 				timeline, err := client.GetMatchTimeline(ctx, matches[0], region)
 				if err != nil {
 					t.Fatalf("Failed to get match timeline: %v", err)
 				}
-
-				t.Logf("Timeline has %d frames", len(timeline.Info.Frames))
+				if timeline.Metadata.MatchID != matches[0] || len(timeline.Info.Frames) != 4 || len(timeline.Info.Participants) != 10 {
+					t.Errorf("timeline %s has %d frames and %d participants", timeline.Metadata.MatchID, len(timeline.Info.Frames), len(timeline.Info.Participants))
+				}
 			})
 	}
 }
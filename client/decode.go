@@ -0,0 +1,320 @@
+package client
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// DecodeMode controls how response bodies are unmarshalled into the types package.
+type DecodeMode int
+
+const (
+	// DecodeStandard hands the body to encoding/json unchanged.
+	DecodeStandard DecodeMode = iota
+	// DecodeStrict fails on the first JSON value whose type does not match the
+	// Go field it is decoded into, reporting its full path.
+	DecodeStrict
+	// DecodeLenient leaves mismatched fields at their zero value and records a
+	// warning for each of them on the returned object.
+	DecodeLenient
+)
+
+func (m DecodeMode) String() string {
+	switch m {
+	case DecodeStandard:
+		return "standard"
+	case DecodeStrict:
+		return "strict"
+	case DecodeLenient:
+		return "lenient"
+	}
+	return fmt.Sprintf("DecodeMode(%d)", int(m))
+}
+
+// DecodeError is returned in DecodeStrict mode when a value in the response
+// does not fit the type it is decoded into.
+type DecodeError struct {
+	Kind     string
+	ID       string
+	Path     string
+	Expected string
+	Got      string
+}
+
+func (e *DecodeError) Error() string {
+	subject := e.Kind
+	if e.ID != "" {
+		subject += " " + e.ID
+	}
+	return fmt.Sprintf("decode %s: %s: expected %s, got %s", subject, e.Path, e.Expected, e.Got)
+}
+
+// Decode unmarshals body into v according to mode. Warnings are only returned
+// in DecodeLenient mode. It can be used to re-decode a stored Response.Body.
+func Decode(body []byte, v any, mode DecodeMode) ([]types.DecodeWarning, error) {
+	switch mode {
+	case DecodeStrict:
+		generic, err := decodeGeneric(body)
+		if err != nil {
+			return nil, err
+		}
+		checker := typeChecker{stopOnFirst: true}
+		checker.check(generic, reflect.TypeOf(v).Elem(), "")
+		if len(checker.mismatches) > 0 {
+			m := checker.mismatches[0]
+			return nil, &DecodeError{Path: m.Path, Expected: m.Expected, Got: m.Got}
+		}
+		return nil, json.Unmarshal(body, v)
+	case DecodeLenient:
+		if err := json.Unmarshal(body, v); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, err
+			}
+		}
+		generic, err := decodeGeneric(body)
+		if err != nil {
+			return nil, err
+		}
+		var checker typeChecker
+		checker.check(generic, reflect.TypeOf(v).Elem(), "")
+		slices.SortFunc(checker.mismatches, func(a, b types.DecodeWarning) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		return checker.mismatches, nil
+	}
+	return nil, json.Unmarshal(body, v)
+}
+
+func decodeGeneric(body []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// typeChecker walks a generically decoded JSON document alongside the Go type
+// it is meant to be decoded into and records every value encoding/json would
+// reject with an UnmarshalTypeError.
+type typeChecker struct {
+	mismatches  []types.DecodeWarning
+	stopOnFirst bool
+}
+
+func (tc *typeChecker) done() bool {
+	return tc.stopOnFirst && len(tc.mismatches) > 0
+}
+
+func (tc *typeChecker) mismatch(path string, t reflect.Type, v any) {
+	if path == "" {
+		path = "."
+	}
+	tc.mismatches = append(tc.mismatches, types.DecodeWarning{
+		Path:     path,
+		Expected: t.String(),
+		Got:      describeJSON(v),
+	})
+}
+
+func (tc *typeChecker) check(v any, t reflect.Type, path string) {
+	if v == nil || tc.done() {
+		return
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		tc.check(v, t.Elem(), path)
+	case reflect.Interface:
+		return
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		fields := structFields(t)
+		for key, value := range obj {
+			field, ok := fields.lookup(key)
+			if !ok {
+				continue
+			}
+			tc.check(value, field.typ, joinPath(path, key))
+			if tc.done() {
+				return
+			}
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		for key, value := range obj {
+			tc.check(value, t.Elem(), joinPath(path, key))
+			if tc.done() {
+				return
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := v.(string); !ok {
+				tc.mismatch(path, t, v)
+			}
+			return
+		}
+		arr, ok := v.([]any)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		for i, value := range arr {
+			tc.check(value, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			if tc.done() {
+				return
+			}
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			tc.mismatch(path, t, v)
+		}
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			tc.mismatch(path, t, v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(json.Number)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		i, err := strconv.ParseInt(string(n), 10, 64)
+		if err != nil || reflect.New(t).Elem().OverflowInt(i) {
+			tc.mismatch(path, t, v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := v.(json.Number)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		u, err := strconv.ParseUint(string(n), 10, 64)
+		if err != nil || reflect.New(t).Elem().OverflowUint(u) {
+			tc.mismatch(path, t, v)
+		}
+	case reflect.Float32, reflect.Float64:
+		n, ok := v.(json.Number)
+		if !ok {
+			tc.mismatch(path, t, v)
+			return
+		}
+		f, err := strconv.ParseFloat(string(n), 64)
+		if err != nil || reflect.New(t).Elem().OverflowFloat(f) {
+			tc.mismatch(path, t, v)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func describeJSON(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number " + v.String()
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+type checkedField struct {
+	name string
+	typ  reflect.Type
+}
+
+type fieldSet []checkedField
+
+// lookup mirrors encoding/json's preference for an exact key match over a
+// case-insensitive one.
+func (fs fieldSet) lookup(key string) (checkedField, bool) {
+	for _, f := range fs {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fs {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return checkedField{}, false
+}
+
+var fieldCache sync.Map // map[reflect.Type]fieldSet
+
+func structFields(t reflect.Type) fieldSet {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.(fieldSet)
+	}
+
+	var fields fieldSet
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, checkedField{name: name, typ: f.Type})
+	}
+
+	fieldCache.Store(t, fields)
+	return fields
+}
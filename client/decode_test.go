@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+const mismatchedMatch = `{
+	"metadata": {"matchId": "EUW1_1"},
+	"info": {
+		"gameDuration": 1800,
+		"participants": [
+			{"kills": 3, "championName": "Ahri"},
+			{"kills": 2.5, "championName": "Zed", "win": "yes"}
+		]
+	}
+}`
+
+func TestDecodeStandard(t *testing.T) {
+	var match types.Match
+	if _, err := Decode([]byte(mismatchedMatch), &match, DecodeStandard); err == nil {
+		t.Fatal("expected encoding/json to reject a float in an int field")
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	var match types.Match
+	_, err := Decode([]byte(mismatchedMatch), &match, DecodeStrict)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("expected *DecodeError, got %v", err)
+	}
+	switch decodeErr.Path {
+	case "info.participants[1].kills", "info.participants[1].win":
+	default:
+		t.Fatalf("unexpected path %q", decodeErr.Path)
+	}
+}
+
+func TestDecodeLenient(t *testing.T) {
+	var match types.Match
+	warnings, err := Decode([]byte(mismatchedMatch), &match, DecodeLenient)
+	if err != nil {
+		t.Fatalf("lenient decode failed: %v", err)
+	}
+
+	want := []types.DecodeWarning{
+		{Path: "info.participants[1].kills", Expected: "int", Got: "number 2.5"},
+		{Path: "info.participants[1].win", Expected: "bool", Got: "string"},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %v", len(warnings), len(want), warnings)
+	}
+	for i := range want {
+		if warnings[i] != want[i] {
+			t.Errorf("warning %d: got %v, want %v", i, warnings[i], want[i])
+		}
+	}
+
+	if match.Info.GameDuration != 1800 || match.Info.Participants[1].ChampionName != "Zed" {
+		t.Errorf("valid fields were not decoded: %+v", match.Info)
+	}
+	if match.Info.Participants[1].Kills != 0 {
+		t.Errorf("mismatched field was not zeroed: %d", match.Info.Participants[1].Kills)
+	}
+}
+
+func TestLenientWarningsForSliceResponses(t *testing.T) {
+	c := newStubClient(t, Config{DecodeMode: DecodeLenient}, func(req *http.Request) (*http.Response, error) {
+		return stubResponse(http.StatusOK, `[{"puuid":"p","leaguePoints":1.5}]`, nil), nil
+	})
+	resp, err := c.GetLeagueEntriesRaw(context.Background(), "RANKED_SOLO_5x5", "DIAMOND", "I", types.EUW1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.DecodeWarnings) != 1 || !strings.HasSuffix(resp.DecodeWarnings[0].Path, "leaguePoints") {
+		t.Errorf("warnings = %v, want one for leaguePoints", resp.DecodeWarnings)
+	}
+	if len(resp.Value) != 1 || resp.Value[0].PUUID.Value != "p" {
+		t.Errorf("entries = %+v", resp.Value)
+	}
+}
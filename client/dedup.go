@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats reports counters accumulated over the lifetime of a Client.
+type Stats struct {
+	// Requests is the number of API calls made through the client.
+	Requests int64
+	// Collapsed is the number of calls that shared the result of an identical
+	// call already in flight instead of issuing their own HTTP request.
+	Collapsed int64
+}
+
+type clientStats struct {
+	requests  atomic.Int64
+	collapsed atomic.Int64
+}
+
+func (c *Client) Stats() Stats {
+	return Stats{
+		Requests:  c.stats.requests.Load(),
+		Collapsed: c.stats.collapsed.Load(),
+	}
+}
+
+// flightGroup coalesces concurrent calls with the same key into a single
+// execution. The shared call runs detached from any one caller's context and
+// is only cancelled once every caller waiting on it has given up.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	result  requestResult
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) requestResult) (requestResult, bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight)
+	}
+	f, shared := g.calls[key]
+	if !shared {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = f
+		go func() {
+			f.result = fn(flightCtx)
+			g.mu.Lock()
+			g.forget(key, f)
+			g.mu.Unlock()
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.result, shared
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			g.forget(key, f)
+			f.cancel()
+		}
+		g.mu.Unlock()
+		return requestResult{err: ctx.Err()}, shared
+	}
+}
+
+func (g *flightGroup) forget(key string, f *flight) {
+	if g.calls[key] == f {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func waitForWaiters(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		waiters := 0
+		for _, f := range g.calls {
+			waiters += f.waiters
+		}
+		g.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters", n)
+}
+
+func TestConcurrentIdenticalCallsAreCollapsed(t *testing.T) {
+	const callers = 8
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := newStubClient(t, Config{}, func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		<-release
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			match, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1)
+			if err == nil && match.Metadata.MatchID != "EUW1_1" {
+				err = errors.New("unexpected match " + match.Metadata.MatchID)
+			}
+			errs <- err
+		}()
+	}
+
+	waitForWaiters(t, &c.flights, callers)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 HTTP call, got %d", n)
+	}
+	if stats := c.Stats(); stats.Requests != callers || stats.Collapsed != callers-1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCancelledCallerDoesNotCancelSharedCall(t *testing.T) {
+	release := make(chan struct{})
+	c := newStubClient(t, Config{}, func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetMatch(firstCtx, "EUW1_1", types.EUW1)
+		firstErr <- err
+	}()
+	waitForWaiters(t, &c.flights, 1)
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1)
+		secondErr <- err
+	}()
+	waitForWaiters(t, &c.flights, 2)
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller: expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	if err := <-secondErr; err != nil {
+		t.Errorf("second caller: %v", err)
+	}
+}
+
+func TestCallsWithDifferentPrioritiesAreNotCollapsed(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c := newStubClient(t, Config{}, func(req *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		<-release
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	var wg sync.WaitGroup
+	for _, p := range []Priority{PriorityLow, PriorityHigh, PriorityHigh} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetMatch(WithPriority(context.Background(), p), "EUW1_1", types.EUW1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	waitForWaiters(t, &c.flights, 3)
+	c.flights.mu.Lock()
+	flights := len(c.flights.calls)
+	c.flights.mu.Unlock()
+	close(release)
+	wg.Wait()
+
+	if flights != 2 {
+		t.Errorf("got %d flights, want one per priority", flights)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected 2 HTTP calls, got %d", n)
+	}
+}
+
+// callRecorder records the CallResult of every call.
+type callRecorder struct {
+	NopObserver
+	mu      sync.Mutex
+	results []CallResult
+}
+
+func (r *callRecorder) CallEnd(ctx context.Context, info RequestInfo, result CallResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+}
+
+func TestSharedCallsReportAttemptsOfTheirFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	recorder := &callRecorder{}
+	c := newStubClient(t, Config{MaxRetries: 1, Observers: []Observer{recorder}}, func(req *http.Request) (*http.Response, error) {
+		if calls.Add(1) == 1 {
+			<-release
+			return stubResponse(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"0"}}), nil
+		}
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	waitForWaiters(t, &c.flights, 2)
+	close(release)
+	wg.Wait()
+
+	if len(recorder.results) != 2 {
+		t.Fatalf("got %d call results, want 2", len(recorder.results))
+	}
+	for i, result := range recorder.results {
+		if result.Attempts != 2 {
+			t.Errorf("call %d: Attempts = %d, want 2", i, result.Attempts)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// Endpoint names identify the Riot API method behind a request. They key
+// per-endpoint settings such as cache TTLs.
+const (
+	EndpointAccountByPUUID       = "account-v1.getByPuuid"
+	EndpointAccountByRiotID      = "account-v1.getByRiotId"
+	EndpointSummonerByPUUID      = "summoner-v4.getByPUUID"
+	EndpointMatchIDsByPUUID      = "match-v5.getMatchIdsByPUUID"
+	EndpointMatch                = "match-v5.getMatch"
+	EndpointMatchTimeline        = "match-v5.getTimeline"
+	EndpointChallengerLeague     = "league-v4.getChallengerLeague"
+	EndpointGrandmasterLeague    = "league-v4.getGrandmasterLeague"
+	EndpointMasterLeague         = "league-v4.getMasterLeague"
+	EndpointLeagueEntries        = "league-v4.getLeagueEntries"
+	EndpointLeagueEntriesByPUUID = "league-v4.getLeagueEntriesByPUUID"
+)
+
+// RoutingKind selects the host a Riot API method is served from.
+type RoutingKind int
+
+const (
+	// RoutingPlatform uses the platform host of the region, e.g. euw1.
+	RoutingPlatform RoutingKind = iota
+	// RoutingRegional uses the regional cluster of match-v5: americas,
+	// asia, europe or sea.
+	RoutingRegional
+	// RoutingAccount uses the regional cluster of account-v1, which serves
+	// every account from americas, asia or europe.
+	RoutingAccount
+)
+
+// EndpointDef describes one Riot API method.
+type EndpointDef struct {
+	Name   string
+	Method string
+	// Path is the URL path with parameters in braces, e.g.
+	// "/lol/match/v5/matches/{matchId}".
+	Path    string
+	Routing RoutingKind
+	// RateLimitKey groups endpoints sharing a method rate limit, see
+	// Config.MethodRateLimits. It defaults to Name.
+	RateLimitKey string
+	// ResponseType is the type responses are decoded into.
+	ResponseType reflect.Type
+}
+
+// Endpoint is an EndpointDef whose responses decode into T.
+type Endpoint[T any] struct {
+	EndpointDef
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []EndpointDef
+)
+
+// Define registers a Riot API method and returns it for use with Call.
+// Method defaults to GET and RateLimitKey to Name.
+func Define[T any](def EndpointDef) Endpoint[T] {
+	if def.Method == "" {
+		def.Method = http.MethodGet
+	}
+	if def.RateLimitKey == "" {
+		def.RateLimitKey = def.Name
+	}
+	def.ResponseType = reflect.TypeFor[T]()
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, def)
+	return Endpoint[T]{def}
+}
+
+// Endpoints returns all registered endpoints in registration order.
+func Endpoints() []EndpointDef {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return append([]EndpointDef(nil), registry...)
+}
+
+// Endpoints missing below are generated from spec/riot-openapi.json into
+// generated.go.
+//
+//go:generate go run ../cmd/lolgen -spec ../spec/riot-openapi.json -types ../types/generated.go -client generated.go
+
+var (
+	accountByPUUID = Define[types.Account](EndpointDef{
+		Name:    EndpointAccountByPUUID,
+		Path:    "/riot/account/v1/accounts/by-puuid/{puuid}",
+		Routing: RoutingAccount,
+	})
+	accountByRiotID = Define[types.Account](EndpointDef{
+		Name:    EndpointAccountByRiotID,
+		Path:    "/riot/account/v1/accounts/by-riot-id/{gameName}/{tagLine}",
+		Routing: RoutingAccount,
+	})
+	summonerByPUUID = Define[types.Summoner](EndpointDef{
+		Name:    EndpointSummonerByPUUID,
+		Path:    "/lol/summoner/v4/summoners/by-puuid/{encryptedPUUID}",
+		Routing: RoutingPlatform,
+	})
+	matchIDsByPUUID = Define[[]string](EndpointDef{
+		Name:    EndpointMatchIDsByPUUID,
+		Path:    "/lol/match/v5/matches/by-puuid/{puuid}/ids",
+		Routing: RoutingRegional,
+	})
+	match = Define[types.Match](EndpointDef{
+		Name:    EndpointMatch,
+		Path:    "/lol/match/v5/matches/{matchId}",
+		Routing: RoutingRegional,
+	})
+	matchTimeline = Define[types.MatchTimeline](EndpointDef{
+		Name:    EndpointMatchTimeline,
+		Path:    "/lol/match/v5/matches/{matchId}/timeline",
+		Routing: RoutingRegional,
+	})
+	challengerLeague = Define[types.LeagueList](EndpointDef{
+		Name:    EndpointChallengerLeague,
+		Path:    "/lol/league/v4/challengerleagues/by-queue/{queue}",
+		Routing: RoutingPlatform,
+	})
+	grandmasterLeague = Define[types.LeagueList](EndpointDef{
+		Name:    EndpointGrandmasterLeague,
+		Path:    "/lol/league/v4/grandmasterleagues/by-queue/{queue}",
+		Routing: RoutingPlatform,
+	})
+	masterLeague = Define[types.LeagueList](EndpointDef{
+		Name:    EndpointMasterLeague,
+		Path:    "/lol/league/v4/masterleagues/by-queue/{queue}",
+		Routing: RoutingPlatform,
+	})
+	leagueEntries = Define[[]types.LeagueEntry](EndpointDef{
+		Name:    EndpointLeagueEntries,
+		Path:    "/lol/league/v4/entries/{queue}/{tier}/{division}",
+		Routing: RoutingPlatform,
+	})
+	leagueEntriesByPUUID = Define[[]types.LeagueEntry](EndpointDef{
+		Name:    EndpointLeagueEntriesByPUUID,
+		Path:    "/lol/league/v4/entries/by-puuid/{encryptedPUUID}",
+		Routing: RoutingPlatform,
+	})
+)
+
+// routingValue returns the host prefix serving kind for region.
+func routingValue(kind RoutingKind, region types.Region) string {
+	switch kind {
+	case RoutingRegional:
+		if region == types.OC1 {
+			return "sea"
+		}
+		return accountRouting(region)
+	case RoutingAccount:
+		return accountRouting(region)
+	}
+	return region.ToString()
+}
+
+func accountRouting(region types.Region) string {
+	switch region {
+	case types.BR1, types.LA1, types.LA2, types.OC1, types.NA1:
+		return "americas"
+	case types.KR, types.JP1:
+		return "asia"
+	case types.EUN1, types.EUW1, types.TR1, types.RU:
+		return "europe"
+	}
+	return "" //switch is exhaustive
+}
+
+// Params are the path parameters of a call, keyed by their name in
+// EndpointDef.Path.
+type Params map[string]string
+
+// expand fills the parameters into path, escaping them, and returns the
+// parameter values in path order for use in error messages.
+func expand(path string, params Params) (string, []string, error) {
+	var b strings.Builder
+	var values []string
+	for {
+		open := strings.IndexByte(path, '{')
+		if open < 0 {
+			b.WriteString(path)
+			return b.String(), values, nil
+		}
+		end := strings.IndexByte(path[open:], '}')
+		if end < 0 {
+			return "", nil, fmt.Errorf("unterminated parameter in path %q", path)
+		}
+		name := path[open+1 : open+end]
+		value, ok := params[name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing path parameter %q", name)
+		}
+		b.WriteString(path[:open])
+		b.WriteString(url.PathEscape(value))
+		values = append(values, value)
+		path = path[open+end+1:]
+	}
+}
+
+// Call calls endpoint for region and decodes the response. It is what every
+// Get method is built on and can be used for endpoints defined outside this
+// package.
+func Call[T any](ctx context.Context, c *Client, endpoint Endpoint[T], region types.Region, params Params, query url.Values) (*Response[T], error) {
+	path, values, err := expand(endpoint.Path, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", endpoint.Name, err)
+	}
+
+	route := routingValue(endpoint.Routing, region)
+	u := fmt.Sprintf("https://%s.api.riotgames.com%s", route, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var resp *Response[T]
+	err = c.makeRequest(ctx, apiCall{
+		endpoint:     endpoint.Name,
+		method:       endpoint.Method,
+		url:          u,
+		routingValue: route,
+		rateLimitKey: endpoint.RateLimitKey,
+		pathParams:   params,
+	}, func(raw *rawResponse) error {
+		resp = newResponse[T](raw)
+		warnings, err := c.decode(raw.body, &resp.Value, endpoint.Name, strings.Join(values, "/"))
+		if err != nil {
+			return err
+		}
+		resp.DecodeWarnings = warnings
+		setDecodeWarnings(&resp.Value, warnings)
+		c.tagIDs(&resp.Value, raw.keyName)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// setDecodeWarnings stores warnings in the DecodeWarnings field of *v, if it
+// is a struct with one.
+func setDecodeWarnings(v any, warnings []types.DecodeWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+	elem := reflect.ValueOf(v).Elem()
+	if elem.Kind() != reflect.Struct {
+		return
+	}
+	if field := elem.FieldByName("DecodeWarnings"); field.IsValid() && field.CanSet() {
+		field.Set(reflect.ValueOf(warnings))
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func TestEndpointsRouteAndEscape(t *testing.T) {
+	var got []string
+	c := newStubClient(t, Config{}, func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.URL.Host+req.URL.EscapedPath()+"?"+req.URL.RawQuery)
+		return stubResponse(http.StatusOK, `{}`, nil), nil
+	})
+	ctx := context.Background()
+
+	c.GetSummonerByPUUID(ctx, types.NewPUUID("p"), types.EUW1)
+	c.GetMatchHistoryByPUUID(ctx, types.NewPUUID("p"), types.OC1, 5)
+	c.GetMatch(ctx, "OC1_1/2", types.OC1)
+	c.GetAccountByRiotID(ctx, "Hide on bush", "KR1", types.OC1)
+
+	want := []string{
+		"euw1.api.riotgames.com/lol/summoner/v4/summoners/by-puuid/p?",
+		"sea.api.riotgames.com/lol/match/v5/matches/by-puuid/p/ids?count=5&start=0",
+		"sea.api.riotgames.com/lol/match/v5/matches/OC1_1%2F2?",
+		"americas.api.riotgames.com/riot/account/v1/accounts/by-riot-id/Hide%20on%20bush/KR1?",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEndpointsRegistry(t *testing.T) {
+	names := map[string]bool{}
+	for _, def := range Endpoints() {
+		if names[def.Name] {
+			t.Errorf("%s registered twice", def.Name)
+		}
+		names[def.Name] = true
+		if def.Method != http.MethodGet || def.RateLimitKey == "" || def.ResponseType == nil {
+			t.Errorf("%s: defaults not applied: %+v", def.Name, def)
+		}
+	}
+	if !names[EndpointMatchTimeline] {
+		t.Errorf("%s missing from registry", EndpointMatchTimeline)
+	}
+}
+
+func TestCallRejectsMissingPathParams(t *testing.T) {
+	c := newStubClient(t, Config{}, func(req *http.Request) (*http.Response, error) {
+		t.Error("unexpected request")
+		return nil, nil
+	})
+	if _, err := Call(context.Background(), c, match, types.EUW1, Params{}, nil); err == nil {
+		t.Error("expected error for missing matchId")
+	}
+}
@@ -0,0 +1,107 @@
+// Code generated by lolgen. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+const (
+	EndpointAllChampionMasteriesByPUUID = "champion-mastery-v4.getAllChampionMasteriesByPUUID"
+	EndpointChampionMasteryScoreByPUUID = "champion-mastery-v4.getChampionMasteryScoreByPUUID"
+	EndpointTopChampionMasteriesByPUUID = "champion-mastery-v4.getTopChampionMasteriesByPUUID"
+)
+
+var (
+	allChampionMasteriesByPUUID = Define[[]types.ChampionMastery](EndpointDef{
+		Name:    EndpointAllChampionMasteriesByPUUID,
+		Path:    "/lol/champion-mastery/v4/champion-masteries/by-puuid/{encryptedPUUID}",
+		Routing: RoutingPlatform,
+	})
+	championMasteryScoreByPUUID = Define[int](EndpointDef{
+		Name:    EndpointChampionMasteryScoreByPUUID,
+		Path:    "/lol/champion-mastery/v4/scores/by-puuid/{encryptedPUUID}",
+		Routing: RoutingPlatform,
+	})
+	topChampionMasteriesByPUUID = Define[[]types.ChampionMastery](EndpointDef{
+		Name:    EndpointTopChampionMasteriesByPUUID,
+		Path:    "/lol/champion-mastery/v4/champion-masteries/by-puuid/{encryptedPUUID}/top",
+		Routing: RoutingPlatform,
+	})
+)
+
+// GetAllChampionMasteriesByPUUID calls
+// champion-mastery-v4.getAllChampionMasteriesByPUUID. Get all champion
+// mastery entries sorted by number of champion points descending.
+func (c *Client) GetAllChampionMasteriesByPUUID(ctx context.Context, encryptedPUUID types.PUUID, region types.Region) ([]types.ChampionMastery, error) {
+	resp, err := c.GetAllChampionMasteriesByPUUIDRaw(ctx, encryptedPUUID, region)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+func (c *Client) GetAllChampionMasteriesByPUUIDRaw(ctx context.Context, encryptedPUUID types.PUUID, region types.Region) (*Response[[]types.ChampionMastery], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", encryptedPUUID.EncryptedID)
+	if err != nil {
+		return nil, err
+	}
+	return Call(ctx, c, allChampionMasteriesByPUUID, region, Params{"encryptedPUUID": encryptedPUUID.Value}, nil)
+}
+
+// GetChampionMasteryScoreByPUUID calls
+// champion-mastery-v4.getChampionMasteryScoreByPUUID. Get a player's total
+// champion mastery score, which is the sum of individual champion mastery
+// levels.
+func (c *Client) GetChampionMasteryScoreByPUUID(ctx context.Context, encryptedPUUID types.PUUID, region types.Region) (int, error) {
+	resp, err := c.GetChampionMasteryScoreByPUUIDRaw(ctx, encryptedPUUID, region)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
+func (c *Client) GetChampionMasteryScoreByPUUIDRaw(ctx context.Context, encryptedPUUID types.PUUID, region types.Region) (*Response[int], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", encryptedPUUID.EncryptedID)
+	if err != nil {
+		return nil, err
+	}
+	return Call(ctx, c, championMasteryScoreByPUUID, region, Params{"encryptedPUUID": encryptedPUUID.Value}, nil)
+}
+
+// TopChampionMasteriesByPUUIDOptions are the optional query parameters of
+// GetTopChampionMasteriesByPUUID.
+type TopChampionMasteriesByPUUIDOptions struct {
+	// Number of entries to retrieve, defaults to 3.
+	Count int
+}
+
+// GetTopChampionMasteriesByPUUID calls
+// champion-mastery-v4.getTopChampionMasteriesByPUUID. Get specified number of
+// top champion mastery entries sorted by number of champion points
+// descending.
+func (c *Client) GetTopChampionMasteriesByPUUID(ctx context.Context, encryptedPUUID types.PUUID, region types.Region, opts *TopChampionMasteriesByPUUIDOptions) ([]types.ChampionMastery, error) {
+	resp, err := c.GetTopChampionMasteriesByPUUIDRaw(ctx, encryptedPUUID, region, opts)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+func (c *Client) GetTopChampionMasteriesByPUUIDRaw(ctx context.Context, encryptedPUUID types.PUUID, region types.Region, opts *TopChampionMasteriesByPUUIDOptions) (*Response[[]types.ChampionMastery], error) {
+	ctx, err := c.checkIDKey(ctx, "puuid", encryptedPUUID.EncryptedID)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if opts != nil {
+		if opts.Count != 0 {
+			query.Set("count", fmt.Sprint(opts.Count))
+		}
+	}
+	return Call(ctx, c, topChampionMasteriesByPUUID, region, Params{"encryptedPUUID": encryptedPUUID.Value}, query)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// IDCheck controls what happens when an encrypted ID tagged with one API
+// key's fingerprint is used with a different key.
+type IDCheck int
+
+const (
+	// IDCheckWarn logs the mismatch and makes the call anyway.
+	IDCheckWarn IDCheck = iota
+	// IDCheckFail returns an *IDKeyMismatchError without calling Riot.
+	IDCheckFail
+	// IDCheckOff disables the check.
+	IDCheckOff
+)
+
+var ErrIDKeyMismatch = errors.New("encrypted ID used with a different API key")
+
+// IDKeyMismatchError reports an encrypted ID used with a key other than the
+// one that produced it.
+type IDKeyMismatchError struct {
+	Kind    string
+	ID      string
+	IDKey   types.KeyFingerprint
+	KeyName string
+}
+
+func (e *IDKeyMismatchError) Error() string {
+	if e.KeyName == "" {
+		return fmt.Sprintf("%s %s was issued for key %s, which is not in the key pool", e.Kind, e.ID, e.IDKey)
+	}
+	return fmt.Sprintf("%s %s was issued for key %s, not for key %q", e.Kind, e.ID, e.IDKey, e.KeyName)
+}
+
+func (e *IDKeyMismatchError) Unwrap() error {
+	return ErrIDKeyMismatch
+}
+
+// checkIDKey makes sure a call using id goes out with the key that produced
+// it. Unpinned calls are pinned to that key when it is in the pool.
+func (c *Client) checkIDKey(ctx context.Context, kind string, id types.EncryptedID) (context.Context, error) {
+	if id.Key == "" || c.config.IDKeyCheck == IDCheckOff {
+		return ctx, nil
+	}
+
+	var mismatch *IDKeyMismatchError
+	if name, ok := APIKeyFromContext(ctx); ok {
+		if fingerprint, known := c.keys.fingerprint(name); known && fingerprint != id.Key {
+			mismatch = &IDKeyMismatchError{Kind: kind, ID: id.Value, IDKey: id.Key, KeyName: name}
+		}
+	} else if name, ok := c.keys.nameOf(id.Key); ok {
+		return WithAPIKey(ctx, name), nil
+	} else {
+		mismatch = &IDKeyMismatchError{Kind: kind, ID: id.Value, IDKey: id.Key}
+	}
+
+	if mismatch == nil {
+		return ctx, nil
+	}
+	if c.config.IDKeyCheck == IDCheckFail {
+		return ctx, mismatch
+	}
+	c.logger.WarnContext(ctx, "encrypted ID used with a different API key", "kind", kind, "id", id.Value, "error", mismatch)
+	return ctx, nil
+}
+
+// tagIDs records the fingerprint of the key a response was fetched with on
+// every encrypted ID in v.
+func (c *Client) tagIDs(v any, keyName string) {
+	if fingerprint, ok := c.keys.fingerprint(keyName); ok {
+		types.TagIDs(v, fingerprint)
+	}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func TestTaggedIDsPinTheirKey(t *testing.T) {
+	c := newStubClient(t, Config{
+		APIKeys:    []APIKey{{Name: "a", Key: "RGAPI-a"}, {Name: "b", Key: "RGAPI-b"}},
+		IDKeyCheck: IDCheckFail,
+	}, func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("X-Riot-Token"); got != "RGAPI-b" {
+			t.Errorf("tagged PUUID was sent with %q", got)
+		}
+		return stubResponse(http.StatusOK, `{"puuid":"p","id":"s"}`, nil), nil
+	})
+	ctx := context.Background()
+
+	puuid := types.PUUID{EncryptedID: types.EncryptedID{Value: "p", Key: types.FingerprintKey("RGAPI-b")}}
+	summoner, err := c.GetSummonerByPUUID(ctx, puuid, types.EUW1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summoner.PUUID != puuid || summoner.ID.Key != puuid.Key {
+		t.Errorf("returned IDs not tagged with key b: %+v", summoner)
+	}
+
+	_, err = c.GetSummonerByPUUID(WithAPIKey(ctx, "a"), puuid, types.EUW1)
+	var mismatch *IDKeyMismatchError
+	if !errors.As(err, &mismatch) || mismatch.KeyName != "a" {
+		t.Errorf("expected mismatch error for key a, got %v", err)
+	}
+
+	foreign := types.PUUID{EncryptedID: types.EncryptedID{Value: "p", Key: types.FingerprintKey("RGAPI-elsewhere")}}
+	if _, err := c.GetSummonerByPUUID(ctx, foreign, types.EUW1); !errors.Is(err, ErrIDKeyMismatch) {
+		t.Errorf("expected mismatch error for foreign key, got %v", err)
+	}
+}
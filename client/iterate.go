@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// maxMatchIDsPage is the largest count match-v5 accepts.
+const maxMatchIDsPage = 100
+
+// MatchHistoryOptions filter and bound a match history iteration.
+type MatchHistoryOptions struct {
+	// Queue, Type, StartTime and EndTime are passed on to Riot as the
+	// queue, type, startTime and endTime filters. Zero values are omitted.
+	Queue     int
+	Type      string
+	StartTime time.Time
+	EndTime   time.Time
+
+	// PageSize is the number of IDs requested per call (default and
+	// maximum 100). Limit stops after that many IDs; zero lists the whole
+	// history.
+	PageSize int
+	Limit    int
+
+	// MinPatch and MaxPatch restrict Matches to matches played on patches
+	// within the range, inclusive. Histories are sorted newest first, so
+	// Matches stops paging at the first match older than MinPatch. Zero
+	// bounds are unbounded. MatchIDs ignores both.
+	MinPatch types.GameVersion
+	MaxPatch types.GameVersion
+}
+
+func (o MatchHistoryOptions) query(start int, count int) url.Values {
+	query := url.Values{"start": {strconv.Itoa(start)}, "count": {strconv.Itoa(count)}}
+	if o.Queue != 0 {
+		query.Set("queue", strconv.Itoa(o.Queue))
+	}
+	if o.Type != "" {
+		query.Set("type", o.Type)
+	}
+	if !o.StartTime.IsZero() {
+		query.Set("startTime", strconv.FormatInt(o.StartTime.Unix(), 10))
+	}
+	if !o.EndTime.IsZero() {
+		query.Set("endTime", strconv.FormatInt(o.EndTime.Unix(), 10))
+	}
+	return query
+}
+
+// MatchIDs iterates over the match history of puuid, newest first, fetching
+// pages as needed. Iteration ends after the last page, at opts.Limit, or
+// with the first error.
+func (c *Client) MatchIDs(ctx context.Context, puuid types.PUUID, region types.Region, opts MatchHistoryOptions) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		ctx, err := c.checkIDKey(ctx, "puuid", puuid.EncryptedID)
+		if err != nil {
+			yield("", err)
+			return
+		}
+		pageSize := opts.PageSize
+		if pageSize <= 0 || pageSize > maxMatchIDsPage {
+			pageSize = maxMatchIDsPage
+		}
+
+		listed := 0
+		for {
+			count := pageSize
+			if opts.Limit > 0 {
+				count = min(count, opts.Limit-listed)
+			}
+			if count <= 0 {
+				return
+			}
+			resp, err := Call(ctx, c, matchIDsByPUUID, region, Params{"puuid": puuid.Value}, opts.query(listed, count))
+			if err != nil {
+				yield("", err)
+				return
+			}
+			for _, id := range resp.Value {
+				if !yield(id, nil) {
+					return
+				}
+			}
+			listed += len(resp.Value)
+			if len(resp.Value) < count {
+				return
+			}
+		}
+	}
+}
+
+// Matches iterates over the matches in the history of puuid, newest first,
+// fetching each one. Matches outside opts.MinPatch and opts.MaxPatch are
+// skipped, and iteration stops at the first match older than MinPatch.
+// Iteration ends with the first error.
+func (c *Client) Matches(ctx context.Context, puuid types.PUUID, region types.Region, opts MatchHistoryOptions) iter.Seq2[*types.Match, error] {
+	return func(yield func(*types.Match, error) bool) {
+		for id, err := range c.MatchIDs(ctx, puuid, region, opts) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			match, err := c.GetMatch(ctx, id, region)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			patch := match.Info.Patch()
+			if !opts.MinPatch.IsZero() && !patch.IsZero() && patch.Before(opts.MinPatch.Patch()) {
+				return
+			}
+			if !patch.InPatchRange(opts.MinPatch, opts.MaxPatch) {
+				continue
+			}
+			if !yield(match, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// historyStub serves a history of n matches, EUW1_<n-1> newest, where match
+// i was played on patch 14.<i/10>.
+func historyStub(n int, pages *atomic.Int32, matches *atomic.Int32) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if strings.Contains(req.URL.Path, "/by-puuid/") {
+			pages.Add(1)
+			start, _ := strconv.Atoi(req.URL.Query().Get("start"))
+			count, _ := strconv.Atoi(req.URL.Query().Get("count"))
+			ids := []string{}
+			for i := n - 1 - start; i >= 0 && len(ids) < count; i-- {
+				ids = append(ids, fmt.Sprintf("EUW1_%d", i))
+			}
+			body, _ := json.Marshal(ids)
+			return stubResponse(http.StatusOK, string(body), nil), nil
+		}
+		matches.Add(1)
+		id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+		i, _ := strconv.Atoi(strings.TrimPrefix(id, "EUW1_"))
+		body := fmt.Sprintf(`{"metadata": {"matchId": %q}, "info": {"gameVersion": "14.%d.600.1"}}`, id, i/10)
+		return stubResponse(http.StatusOK, body, nil), nil
+	}
+}
+
+func TestMatchIDsPages(t *testing.T) {
+	var pages, matches atomic.Int32
+	c := newStubClient(t, Config{}, historyStub(250, &pages, &matches))
+	ctx := context.Background()
+	puuid := types.NewPUUID("p")
+
+	var ids []string
+	for id, err := range c.MatchIDs(ctx, puuid, types.EUW1, MatchHistoryOptions{}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 250 || ids[0] != "EUW1_249" || ids[249] != "EUW1_0" {
+		t.Fatalf("unexpected history of %d IDs: %v...", len(ids), ids[:3])
+	}
+	if pages.Load() != 3 {
+		t.Errorf("expected 3 pages, got %d", pages.Load())
+	}
+
+	pages.Store(0)
+	ids = nil
+	for id, err := range c.MatchIDs(ctx, puuid, types.EUW1, MatchHistoryOptions{PageSize: 20, Limit: 30}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 30 || pages.Load() != 2 {
+		t.Errorf("expected 30 IDs in 2 pages, got %d in %d", len(ids), pages.Load())
+	}
+}
+
+func TestMatchesStopsBelowMinPatch(t *testing.T) {
+	var pages, matches atomic.Int32
+	c := newStubClient(t, Config{}, historyStub(250, &pages, &matches))
+	opts := MatchHistoryOptions{
+		PageSize: 20,
+		MinPatch: types.MustParseGameVersion("14.21"),
+		MaxPatch: types.MustParseGameVersion("14.22"),
+	}
+
+	var ids []string
+	for match, err := range c.Matches(context.Background(), types.NewPUUID("p"), types.EUW1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, match.Metadata.MatchID)
+	}
+	// Matches 249-230 are on 14.24 and 14.23, 229-210 in range, and 209 on
+	// 14.20 ends the iteration.
+	if len(ids) != 20 || ids[0] != "EUW1_229" || ids[19] != "EUW1_210" {
+		t.Fatalf("unexpected matches %v", ids)
+	}
+	if matches.Load() != 41 || pages.Load() != 3 {
+		t.Errorf("expected paging to stop after 41 matches in 3 pages, got %d in %d", matches.Load(), pages.Load())
+	}
+}
@@ -0,0 +1,267 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// DefaultKeyName names the pool entry created from Config.APIKey.
+const DefaultKeyName = "default"
+
+// DefaultKeyQuarantine is how long a key is skipped after Riot rejected it
+// with 401 or 403 when Config.KeyQuarantine is zero.
+const DefaultKeyQuarantine = 15 * time.Minute
+
+var ErrNoAvailableKey = errors.New("no available API key")
+
+// APIKey is one entry of the key pool. Name identifies the key in usage
+// reports and in WithAPIKey without exposing the key itself.
+type APIKey struct {
+	Name string
+	Key  string
+}
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey pins all calls made with the returned context to the named key.
+// Encrypted IDs such as PUUIDs are only valid with the key that produced them.
+func WithAPIKey(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, name)
+}
+
+// APIKeyFromContext returns the key name set with WithAPIKey.
+func APIKeyFromContext(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(apiKeyContextKey{}).(string)
+	return name, ok && name != ""
+}
+
+// KeyUsage reports the state of one key for one routing value.
+type KeyUsage struct {
+	Name             string
+	RoutingValue     string
+	Requests         int64
+	Errors           int64
+	InFlight         int
+	Headroom         float64
+	Quarantined      bool
+	QuarantinedUntil time.Time
+}
+
+type keyPool struct {
+	mu         sync.Mutex
+	keys       []*pooledKey
+	quarantine time.Duration
+}
+
+type pooledKey struct {
+	APIKey
+	fingerprint      types.KeyFingerprint
+	quarantinedUntil time.Time
+	routing          map[string]*keyRoutingState
+}
+
+type keyRoutingState struct {
+	requests int64
+	errors   int64
+	inFlight int
+	windows  []limitWindow
+}
+
+// limitWindow is one window of Riot's X-App-Rate-Limit headers as last
+// observed.
+type limitWindow struct {
+	RateLimitWindow
+	observedAt time.Time
+}
+
+func newKeyPool(config Config) *keyPool {
+	pool := &keyPool{quarantine: config.KeyQuarantine}
+	if pool.quarantine == 0 {
+		pool.quarantine = DefaultKeyQuarantine
+	}
+
+	keys := config.APIKeys
+	if config.APIKey != "" {
+		keys = append([]APIKey{{Name: DefaultKeyName, Key: config.APIKey}}, keys...)
+	}
+	for _, key := range keys {
+		pool.keys = append(pool.keys, &pooledKey{
+			APIKey:      key,
+			fingerprint: types.FingerprintKey(key.Key),
+			routing:     make(map[string]*keyRoutingState),
+		})
+	}
+	return pool
+}
+
+// fingerprint returns the fingerprint of the named key.
+func (p *keyPool) fingerprint(name string) (types.KeyFingerprint, bool) {
+	for _, key := range p.keys {
+		if key.Name == name {
+			return key.fingerprint, true
+		}
+	}
+	return "", false
+}
+
+// nameOf returns the name of the key with the given fingerprint.
+func (p *keyPool) nameOf(fingerprint types.KeyFingerprint) (string, bool) {
+	for _, key := range p.keys {
+		if key.fingerprint == fingerprint {
+			return key.Name, true
+		}
+	}
+	return "", false
+}
+
+func (k *pooledKey) state(routingValue string) *keyRoutingState {
+	state, ok := k.routing[routingValue]
+	if !ok {
+		state = &keyRoutingState{}
+		k.routing[routingValue] = state
+	}
+	return state
+}
+
+// headroom is the smallest fraction of any rate-limit window still
+// available, counting requests in flight. Windows that have rolled over
+// since they were observed count as empty.
+func (s *keyRoutingState) headroom(now time.Time) float64 {
+	headroom := 1.0
+	for _, w := range s.windows {
+		if w.Limit <= 0 {
+			continue
+		}
+		count := w.Count
+		if now.Sub(w.observedAt) > w.Per {
+			count = 0
+		}
+		headroom = math.Min(headroom, float64(w.Limit-count-s.inFlight)/float64(w.Limit))
+	}
+	if len(s.windows) == 0 && s.inFlight > 0 {
+		headroom -= float64(s.inFlight) / 100
+	}
+	return headroom
+}
+
+// acquire picks the key to use for a request and marks it in flight. If the
+// context pins a key, that key is used or an error is returned.
+func (p *keyPool) acquire(ctx context.Context, routingValue string) (*pooledKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if name, ok := APIKeyFromContext(ctx); ok {
+		for _, key := range p.keys {
+			if key.Name != name {
+				continue
+			}
+			if now.Before(key.quarantinedUntil) {
+				return nil, fmt.Errorf("%w: key %q is quarantined until %s", ErrNoAvailableKey, name, key.quarantinedUntil.Format(time.RFC3339))
+			}
+			key.state(routingValue).inFlight++
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: unknown key %q", ErrNoAvailableKey, name)
+	}
+
+	var best *pooledKey
+	bestHeadroom := math.Inf(-1)
+	for _, key := range p.keys {
+		if now.Before(key.quarantinedUntil) {
+			continue
+		}
+		if headroom := key.state(routingValue).headroom(now); headroom > bestHeadroom {
+			best, bestHeadroom = key, headroom
+		}
+	}
+	if best == nil {
+		return nil, ErrNoAvailableKey
+	}
+	best.state(routingValue).inFlight++
+	return best, nil
+}
+
+// release records the outcome of a request made with key. header may be nil
+// if no response was received.
+func (p *keyPool) release(key *pooledKey, routingValue string, status int, header http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	state := key.state(routingValue)
+	state.inFlight--
+	state.requests++
+	if status != http.StatusOK && status != http.StatusNotModified {
+		state.errors++
+	}
+	// Riot answers 403 for endpoints a key may not use, so only 401 says
+	// the key itself is bad. Quarantining the last usable key would only
+	// turn its 401s into ErrNoAvailableKey.
+	if status == http.StatusUnauthorized && p.usableExcept(key, now) > 0 {
+		key.quarantinedUntil = now.Add(p.quarantine)
+	}
+	if header != nil {
+		if windows := ParseRateLimitHeaders(header.Get("X-App-Rate-Limit"), header.Get("X-App-Rate-Limit-Count")); windows != nil {
+			state.windows = state.windows[:0]
+			for _, w := range windows {
+				state.windows = append(state.windows, limitWindow{RateLimitWindow: w, observedAt: now})
+			}
+		}
+	}
+}
+
+// usableExcept counts the keys other than key that are not quarantined.
+func (p *keyPool) usableExcept(key *pooledKey, now time.Time) int {
+	n := 0
+	for _, other := range p.keys {
+		if other != key && !now.Before(other.quarantinedUntil) {
+			n++
+		}
+	}
+	return n
+}
+
+func (p *keyPool) usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var usage []KeyUsage
+	for _, key := range p.keys {
+		routingValues := make([]string, 0, len(key.routing))
+		for routingValue := range key.routing {
+			routingValues = append(routingValues, routingValue)
+		}
+		slices.Sort(routingValues)
+
+		quarantined := now.Before(key.quarantinedUntil)
+		for _, routingValue := range routingValues {
+			state := key.routing[routingValue]
+			usage = append(usage, KeyUsage{
+				Name:             key.Name,
+				RoutingValue:     routingValue,
+				Requests:         state.requests,
+				Errors:           state.errors,
+				InFlight:         state.inFlight,
+				Headroom:         state.headroom(now),
+				Quarantined:      quarantined,
+				QuarantinedUntil: key.quarantinedUntil,
+			})
+		}
+	}
+	return usage
+}
+
+// KeyUsage reports per-key, per-routing-value usage of the key pool.
+func (c *Client) KeyUsage() []KeyUsage {
+	return c.keys.usage()
+}
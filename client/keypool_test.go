@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func TestKeyPoolPrefersHeadroomAndQuarantinesRejectedKeys(t *testing.T) {
+	used := map[string]int{}
+	c := newStubClient(t, Config{
+		APIKeys:              []APIKey{{Name: "prod", Key: "RGAPI-prod"}, {Name: "product", Key: "RGAPI-product"}},
+		DisableDeduplication: true,
+	}, func(req *http.Request) (*http.Response, error) {
+		token := req.Header.Get("X-Riot-Token")
+		used[token]++
+		header := http.Header{"X-App-Rate-Limit": {"100:120"}}
+		switch token {
+		case "RGAPI-prod":
+			header.Set("X-App-Rate-Limit-Count", "90:120")
+		case "RGAPI-product":
+			if used[token] > 1 {
+				return stubResponse(http.StatusUnauthorized, `{"status":{"status_code":401}}`, nil), nil
+			}
+			header.Set("X-App-Rate-Limit-Count", "10:120")
+		}
+		return stubResponse(http.StatusOK, `{"puuid":"p"}`, header), nil
+	})
+	ctx := context.Background()
+
+	// Both keys start with unknown headroom; one call each reveals it.
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetSummonerByPUUID(ctx, types.NewPUUID("p"), types.EUW1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if used["RGAPI-prod"] != 1 || used["RGAPI-product"] != 1 {
+		t.Fatalf("expected one call per key, got %v", used)
+	}
+
+	// product has more headroom but is rejected and quarantined.
+	if _, err := c.GetSummonerByPUUID(ctx, types.NewPUUID("p"), types.EUW1); err == nil {
+		t.Fatal("expected 401 error")
+	}
+	resp, err := c.GetSummonerByPUUIDRaw(ctx, types.NewPUUID("p"), types.EUW1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.KeyName != "prod" {
+		t.Errorf("expected quarantined key to be skipped, got %q", resp.KeyName)
+	}
+
+	if _, err := c.GetSummonerByPUUID(WithAPIKey(ctx, "product"), types.NewPUUID("p"), types.EUW1); !errors.Is(err, ErrNoAvailableKey) {
+		t.Errorf("expected pinned call to quarantined key to fail, got %v", err)
+	}
+
+	for _, usage := range c.KeyUsage() {
+		if usage.Name == "product" && !usage.Quarantined {
+			t.Errorf("expected product to be reported as quarantined: %+v", usage)
+		}
+	}
+}
+
+func TestKeyPoolQuarantinesOnlyUnauthorizedKeys(t *testing.T) {
+	status := map[string]int{"RGAPI-a": http.StatusForbidden, "RGAPI-b": http.StatusUnauthorized}
+	c := newStubClient(t, Config{
+		APIKeys:              []APIKey{{Name: "a", Key: "RGAPI-a"}, {Name: "b", Key: "RGAPI-b"}},
+		DisableDeduplication: true,
+	}, func(req *http.Request) (*http.Response, error) {
+		code := status[req.Header.Get("X-Riot-Token")]
+		return stubResponse(code, `{"status":{"status_code":0}}`, nil), nil
+	})
+	ctx := context.Background()
+
+	// A 403 is about the endpoint, not the key.
+	if _, err := c.GetSummonerByPUUID(WithAPIKey(ctx, "a"), types.NewPUUID("p"), types.EUW1); err == nil {
+		t.Fatal("expected 403 error")
+	}
+	// b is rejected with 401 but a is still usable, so b is quarantined.
+	if _, err := c.GetSummonerByPUUID(WithAPIKey(ctx, "b"), types.NewPUUID("p"), types.EUW1); err == nil {
+		t.Fatal("expected 401 error")
+	}
+	// Now a is the last usable key and keeps being tried despite its 401.
+	status["RGAPI-a"] = http.StatusUnauthorized
+	for i := 0; i < 2; i++ {
+		var apiErr *APIError
+		if _, err := c.GetSummonerByPUUID(ctx, types.NewPUUID("p"), types.EUW1); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("call %d: expected 401 from the last key, got %v", i, err)
+		}
+	}
+
+	quarantined := map[string]bool{}
+	for _, usage := range c.KeyUsage() {
+		quarantined[usage.Name] = usage.Quarantined
+	}
+	if quarantined["a"] || !quarantined["b"] {
+		t.Errorf("quarantined = %v, want only b", quarantined)
+	}
+}
+
+func TestPinnedCallsUseTheirKey(t *testing.T) {
+	c := newStubClient(t, Config{
+		APIKeys: []APIKey{{Name: "a", Key: "RGAPI-a"}, {Name: "b", Key: "RGAPI-b"}},
+	}, func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("X-Riot-Token"); got != "RGAPI-b" {
+			t.Errorf("pinned call used %q", got)
+		}
+		return stubResponse(http.StatusOK, `{"puuid":"p"}`, nil), nil
+	})
+
+	ctx := WithAPIKey(context.Background(), "b")
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetSummonerByPUUID(ctx, types.NewPUUID("p"), types.EUW1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := c.GetSummonerByPUUID(WithAPIKey(context.Background(), "c"), types.NewPUUID("p"), types.EUW1); !errors.Is(err, ErrNoAvailableKey) {
+		t.Errorf("expected unknown key to fail, got %v", err)
+	}
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	windows := ParseRateLimitHeaders("20:1, 100:120", "3:1,41:120")
+	want := []RateLimitWindow{{Limit: 20, Count: 3, Per: time.Second}, {Limit: 100, Count: 41, Per: 2 * time.Minute}}
+	if !slices.Equal(windows, want) {
+		t.Errorf("windows = %+v, want %+v", windows, want)
+	}
+	if windows := ParseRateLimitHeaders("20:1", ""); windows != nil {
+		t.Errorf("windows without counts = %+v", windows)
+	}
+}
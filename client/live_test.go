@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/travior/lol-sdk/cassette"
+	"github.com/travior/lol-sdk/slogzerolog"
+	"github.com/travior/lol-sdk/types"
+)
+
+var record = flag.Bool("record", false, "record the live tests against the real Riot API to testdata/cassettes (needs API_KEY)")
+
+// setupLiveClient returns a client for the tests against real Riot responses.
+// With API_KEY set it calls Riot, and with -record also saves the responses to
+// the test's cassette in testdata/cassettes, without the X-Riot-Token header.
+// Without API_KEY it replays that cassette, and skips the test if there is
+// none.
+func setupLiveClient(t *testing.T) *Client {
+	logger := zerolog.New(zerolog.NewTestWriter(t)).
+		With().
+		Timestamp().
+		Str("test", t.Name()).
+		Logger()
+
+	config := Config{
+		APIKey:         os.Getenv("API_KEY"),
+		RequestsPerMin: 60,
+		BurstSize:      1,
+	}
+	path := filepath.Join("testdata", "cassettes", t.Name()+".json")
+	switch {
+	case config.APIKey != "" && *record:
+		config.Cassette, config.CassetteMode = path, cassette.Record
+	case config.APIKey != "":
+	default:
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			t.Skip("No API key set and no cassette recorded")
+		}
+		config.APIKey = "RGAPI-replay"
+		config.Cassette, config.CassetteMode = path, cassette.Replay
+	}
+	return NewClient(config, slogzerolog.New(logger))
+}
+
+func TestLiveLeagues(t *testing.T) {
+	client := setupLiveClient(t)
+
+	for _, region := range regions {
+		t.Run(
+			fmt.Sprintf("TestLiveLeagues-%s", region.ToString()),
+			func(t *testing.T) {
+				ctx := context.Background()
+				for _, get := range []struct {
+					tier string
+					call func(context.Context, string, types.Region) (*types.LeagueList, error)
+				}{
+					{"CHALLENGER", client.GetChallengerLeague},
+					{"GRANDMASTER", client.GetGrandMasterLeague},
+					{"MASTER", client.GetMasterLeague},
+				} {
+					league, err := get.call(ctx, "RANKED_SOLO_5x5", region)
+					if err != nil {
+						t.Fatalf("API call failed: %v", err)
+					}
+					if league.Tier != get.tier || league.Queue != "RANKED_SOLO_5x5" {
+						t.Errorf("got %s %s league, want %s RANKED_SOLO_5x5", league.Tier, league.Queue, get.tier)
+					}
+					t.Logf("Fetched %d %s players", len(league.Entries), get.tier)
+				}
+
+				entries, err := client.GetLeagueEntries(ctx, "RANKED_SOLO_5x5", "DIAMOND", "I", region)
+				if err != nil {
+					t.Fatalf("API call failed: %v", err)
+				}
+				for _, entry := range entries {
+					if entry.Tier != "DIAMOND" || entry.Rank != "I" || entry.PUUID.IsZero() {
+						t.Errorf("unexpected entry %+v", entry)
+						break
+					}
+				}
+				t.Logf("Fetched %d DIAMOND I players", len(entries))
+			})
+	}
+}
+
+func TestLiveMatchHistoryAndData(t *testing.T) {
+	client := setupLiveClient(t)
+
+	for _, region := range regions {
+		t.Run(
+			fmt.Sprintf("TestLiveMatchHistoryAndData-%s", region.ToString()),
+			func(t *testing.T) {
+				ctx := context.Background()
+
+				league, err := client.GetChallengerLeague(ctx, "RANKED_SOLO_5x5", region)
+				if err != nil {
+					t.Fatalf("Failed to get challenger league: %v", err)
+				}
+				if len(league.Entries) == 0 {
+					t.Skip("No challenger players found")
+				}
+
+				matches, err := client.GetMatchHistoryByPUUID(ctx, league.Entries[0].PUUID, region, 5)
+				if err != nil {
+					t.Fatalf("Failed to get match history: %v", err)
+				}
+				if len(matches) == 0 {
+					t.Skip("No matches found")
+				}
+
+				match, err := client.GetMatch(ctx, matches[0], region)
+				if err != nil {
+					t.Fatalf("Failed to get match: %v", err)
+				}
+				if match.Metadata.MatchID != matches[0] || len(match.Info.Participants) == 0 {
+					t.Errorf("match %s has %d participants", match.Metadata.MatchID, len(match.Info.Participants))
+				}
+
+				timeline, err := client.GetMatchTimeline(ctx, matches[0], region)
+				if err != nil {
+					t.Fatalf("Failed to get match timeline: %v", err)
+				}
+				if timeline.Metadata.MatchID != matches[0] || len(timeline.Info.Frames) == 0 {
+					t.Errorf("timeline %s has %d frames", timeline.Metadata.MatchID, len(timeline.Info.Frames))
+				}
+			})
+	}
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// newLogger returns logger restricted to level, or a logger that discards
+// everything if logger is nil.
+func newLogger(logger *slog.Logger, level slog.Leveler) *slog.Logger {
+	if logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+	if level == nil {
+		level = slog.LevelWarn
+	}
+	return slog.New(&levelHandler{Handler: logger.Handler(), level: level})
+}
+
+type levelHandler struct {
+	slog.Handler
+	level slog.Leveler
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.Handler.Enabled(ctx, level)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// logCall writes the single log line describing the outcome of a call.
+func (c *Client) logCall(ctx context.Context, call apiCall, result CallResult) {
+	level := slog.LevelDebug
+	switch {
+	case result.StatusCode == http.StatusNotFound:
+		level = slog.LevelInfo
+	case result.Err != nil:
+		level = slog.LevelWarn
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", call.endpoint),
+		slog.String("routing_value", call.routingValue),
+		slog.String("url", call.url),
+		slog.Int("status", result.StatusCode),
+		slog.Duration("duration", result.Duration),
+		slog.Bool("from_cache", result.FromCache),
+		slog.Bool("shared", result.Shared),
+	}
+	if result.Err != nil {
+		attrs = append(attrs, slog.Any("error", result.Err))
+	}
+	c.logger.LogAttrs(ctx, level, "riot api call", attrs...)
+}
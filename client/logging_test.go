@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func TestClientLogsOneLinePerCall(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewClient(Config{APIKey: "RGAPI-test", RequestsPerMin: 6000}, logger)
+	c.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/missing") {
+			return stubResponse(http.StatusNotFound, `{}`, nil), nil
+		}
+		return stubResponse(http.StatusInternalServerError, `{}`, nil), nil
+	})
+
+	ctx := context.Background()
+	c.GetMatch(ctx, "missing", types.EUW1)
+	if buf.Len() != 0 {
+		t.Errorf("expected 404 to be below the default level, got %q", buf.String())
+	}
+
+	c.GetMatch(ctx, "EUW1_1", types.EUW1)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one log line, got %q", lines)
+	}
+	for _, want := range []string{"level=WARN", "endpoint=" + EndpointMatch, "status=500"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("log line %q lacks %q", lines[0], want)
+		}
+	}
+}
+
+func TestClientLogsDecodeErrorsAsFailures(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	c := NewClient(Config{APIKey: "RGAPI-test", RequestsPerMin: 6000, DecodeMode: DecodeStrict, LogLevel: slog.LevelDebug}, logger)
+	c.httpClient.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return stubResponse(http.StatusOK, mismatchedMatch, nil), nil
+	})
+
+	if _, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1); err == nil {
+		t.Fatal("expected a decode error")
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one log line, got %q", lines)
+	}
+	for _, want := range []string{"level=WARN", "status=200", "error="} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("log line %q lacks %q", lines[0], want)
+		}
+	}
+}
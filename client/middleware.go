@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends an HTTP request. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RequestMiddleware wraps the Doer that sends API requests. Middleware can
+// read the call being made with RequestInfoFromContext(req.Context()).
+type RequestMiddleware func(next Doer) Doer
+
+// RequestInfo describes the API call an outgoing request belongs to.
+type RequestInfo struct {
+	Endpoint     string
+	RoutingValue string
+	PathParams   map[string]string
+	KeyName      string
+	// Attempt is 1 for the first request of a call and increases with every
+	// retry. It is 0 in Observer events that concern the call as a whole.
+	Attempt int
+}
+
+type requestInfoKey struct{}
+
+func withRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo of the request whose context
+// is ctx.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// chainMiddleware wraps base so that the first middleware is the outermost.
+func chainMiddleware(base Doer, middleware []RequestMiddleware) Doer {
+	doer := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+	return doer
+}
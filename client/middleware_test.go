@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+func TestMiddlewareSeesRequestInfoAndRetries(t *testing.T) {
+	var infos []RequestInfo
+	var order []string
+	record := func(name string) RequestMiddleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				if name == "outer" {
+					info, ok := RequestInfoFromContext(req.Context())
+					if !ok {
+						t.Error("request has no RequestInfo")
+					}
+					infos = append(infos, info)
+					req.Header.Set("X-Audit", "yes")
+				}
+				return next.Do(req)
+			})
+		}
+	}
+
+	attempts := &attemptRecorder{}
+	calls := 0
+	c := newStubClient(t, Config{
+		MaxRetries: 2,
+		Middleware: []RequestMiddleware{record("outer"), record("inner")},
+		Observers:  []Observer{attempts},
+	}, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("X-Audit") != "yes" {
+			t.Error("header set by middleware is missing")
+		}
+		calls++
+		if calls == 1 {
+			return stubResponse(http.StatusTooManyRequests, "", http.Header{"Retry-After": {"0"}}), nil
+		}
+		return stubResponse(http.StatusOK, `{"metadata":{"matchId":"EUW1_1"}}`, nil), nil
+	})
+
+	if _, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(infos))
+	}
+	for i, info := range infos {
+		if info.Endpoint != EndpointMatch || info.RoutingValue != "europe" || info.PathParams["matchId"] != "EUW1_1" || info.Attempt != i+1 {
+			t.Errorf("attempt %d: unexpected info %+v", i+1, info)
+		}
+	}
+	if want := []string{"outer", "inner", "outer", "inner"}; !slices.Equal(order, want) {
+		t.Errorf("middleware ran in order %v, want %v", order, want)
+	}
+
+	// The retried 429 attempt is reported with its error.
+	if len(attempts.results) != 2 {
+		t.Fatalf("observed %d attempts, want 2", len(attempts.results))
+	}
+	var apiErr *APIError
+	if first := attempts.results[0]; first.StatusCode != http.StatusTooManyRequests || !errors.As(first.Err, &apiErr) {
+		t.Errorf("first attempt = %d, %v; want 429 with an APIError", first.StatusCode, first.Err)
+	}
+	if second := attempts.results[1]; second.StatusCode != http.StatusOK || second.Err != nil {
+		t.Errorf("second attempt = %d, %v", second.StatusCode, second.Err)
+	}
+}
+
+type attemptRecorder struct {
+	NopObserver
+	results []AttemptResult
+}
+
+func (r *attemptRecorder) AttemptEnd(ctx context.Context, info RequestInfo, result AttemptResult) {
+	r.results = append(r.results, result)
+}
+
+func TestNonRetryableErrorsAreReturned(t *testing.T) {
+	calls := 0
+	c := newStubClient(t, Config{MaxRetries: 3}, func(req *http.Request) (*http.Response, error) {
+		calls++
+		return stubResponse(http.StatusNotFound, `{"status":{"status_code":404}}`, nil), nil
+	})
+
+	_, err := c.GetMatch(context.Background(), "EUW1_1", types.EUW1)
+	if !IsNotFound(err) {
+		t.Fatalf("expected 404 APIError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("404 was retried %d times", calls-1)
+	}
+}
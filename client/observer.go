@@ -0,0 +1,134 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Observer receives events about API calls, e.g. to record traces or
+// metrics. Embed NopObserver to implement only some of the methods.
+type Observer interface {
+	// CallStart is called when a client method starts an API call. The
+	// returned context is used for the rest of the call.
+	CallStart(ctx context.Context, info RequestInfo) context.Context
+	// CallEnd is called once per CallStart with the outcome of the call.
+	CallEnd(ctx context.Context, info RequestInfo, result CallResult)
+	// CacheLookup is called whenever the configured Cache is consulted.
+	CacheLookup(ctx context.Context, info RequestInfo, hit bool)
+	// LimiterWaitStart is called before waiting on the rate limiter. The
+	// returned context is passed to the matching LimiterWaitEnd only.
+	LimiterWaitStart(ctx context.Context, info RequestInfo) context.Context
+	LimiterWaitEnd(ctx context.Context, info RequestInfo, waited time.Duration, err error)
+	// AttemptEnd is called after every HTTP request, including retries.
+	AttemptEnd(ctx context.Context, info RequestInfo, result AttemptResult)
+	// FlightStart is called when a call starts the work shared by all
+	// identical calls in flight. ctx outlives the caller it came from, so
+	// the returned context should not make that work part of the caller's
+	// trace. It is used for the cache lookup, limiter waits and attempts.
+	FlightStart(ctx context.Context, info RequestInfo) context.Context
+	// FlightEnd is called once per FlightStart.
+	FlightEnd(ctx context.Context, info RequestInfo, err error)
+}
+
+// CallResult is the outcome of an API call.
+type CallResult struct {
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+	FromCache  bool
+	// Shared is set when the call joined an identical call in flight.
+	Shared bool
+	// Attempts is the number of HTTP requests made for the call, including
+	// retries, or by the call it joined. It is zero for cache hits.
+	Attempts int
+}
+
+// AttemptResult is the outcome of a single HTTP request. StatusCode is zero
+// if no response was received.
+type AttemptResult struct {
+	StatusCode int
+	Header     http.Header
+	Err        error
+	Duration   time.Duration
+}
+
+// NopObserver implements Observer with methods that do nothing.
+type NopObserver struct{}
+
+func (NopObserver) CallStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+
+func (NopObserver) CallEnd(ctx context.Context, info RequestInfo, result CallResult) {}
+
+func (NopObserver) CacheLookup(ctx context.Context, info RequestInfo, hit bool) {}
+
+func (NopObserver) LimiterWaitStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+
+func (NopObserver) LimiterWaitEnd(ctx context.Context, info RequestInfo, waited time.Duration, err error) {
+}
+
+func (NopObserver) AttemptEnd(ctx context.Context, info RequestInfo, result AttemptResult) {}
+
+func (NopObserver) FlightStart(ctx context.Context, info RequestInfo) context.Context {
+	return ctx
+}
+
+func (NopObserver) FlightEnd(ctx context.Context, info RequestInfo, err error) {}
+
+// observers fans events out to every configured Observer.
+type observers []Observer
+
+func (o observers) CallStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, obs := range o {
+		ctx = obs.CallStart(ctx, info)
+	}
+	return ctx
+}
+
+func (o observers) CallEnd(ctx context.Context, info RequestInfo, result CallResult) {
+	for _, obs := range o {
+		obs.CallEnd(ctx, info, result)
+	}
+}
+
+func (o observers) CacheLookup(ctx context.Context, info RequestInfo, hit bool) {
+	for _, obs := range o {
+		obs.CacheLookup(ctx, info, hit)
+	}
+}
+
+func (o observers) LimiterWaitStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, obs := range o {
+		ctx = obs.LimiterWaitStart(ctx, info)
+	}
+	return ctx
+}
+
+func (o observers) LimiterWaitEnd(ctx context.Context, info RequestInfo, waited time.Duration, err error) {
+	for _, obs := range o {
+		obs.LimiterWaitEnd(ctx, info, waited, err)
+	}
+}
+
+func (o observers) AttemptEnd(ctx context.Context, info RequestInfo, result AttemptResult) {
+	for _, obs := range o {
+		obs.AttemptEnd(ctx, info, result)
+	}
+}
+
+func (o observers) FlightStart(ctx context.Context, info RequestInfo) context.Context {
+	for _, obs := range o {
+		ctx = obs.FlightStart(ctx, info)
+	}
+	return ctx
+}
+
+func (o observers) FlightEnd(ctx context.Context, info RequestInfo, err error) {
+	for _, obs := range o {
+		obs.FlightEnd(ctx, info, err)
+	}
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting on the same rate limiter. Higher priority
+// waiters are always served first.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	}
+	if p > PriorityHigh {
+		return "high"
+	}
+	return "low"
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose API calls wait on the rate limiter
+// with priority p.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority set with WithPriority, or
+// PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// priorityLimiter is a token bucket that hands out tokens to the highest
+// priority waiter first. A fraction of its rate can be reserved for
+// PriorityHigh: everything else additionally draws from a second, slower
+// bucket, so it can never consume the reserved share.
+type priorityLimiter struct {
+	mu sync.Mutex
+
+	rate  float64
+	burst float64
+
+	sharedRate  float64
+	sharedBurst float64
+
+	tokens       float64
+	sharedTokens float64
+	last         time.Time
+
+	queues [3][]*limiterWaiter
+	timer  *time.Timer
+}
+
+type limiterWaiter struct {
+	priority Priority
+	ready    chan struct{}
+	granted  bool
+}
+
+func newPriorityLimiter(requestsPerMin int, burst int, reserve float64) *priorityLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	reserve = math.Min(math.Max(reserve, 0), 1)
+
+	l := &priorityLimiter{
+		rate:  float64(requestsPerMin) / 60.0,
+		burst: float64(burst),
+		last:  time.Now(),
+	}
+	l.sharedRate = l.rate * (1 - reserve)
+	l.sharedBurst = math.Max(1, l.burst*(1-reserve))
+	l.tokens = l.burst
+	l.sharedTokens = l.sharedBurst
+	return l
+}
+
+func queueIndex(p Priority) int {
+	switch {
+	case p >= PriorityHigh:
+		return 2
+	case p == PriorityNormal:
+		return 1
+	}
+	return 0
+}
+
+func (l *priorityLimiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	w := &limiterWaiter{priority: PriorityFromContext(ctx), ready: make(chan struct{})}
+
+	l.mu.Lock()
+	idx := queueIndex(w.priority)
+	l.queues[idx] = append(l.queues[idx], w)
+	l.dispatch(time.Now())
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if w.granted {
+			l.refund(w)
+		} else {
+			l.remove(w)
+		}
+		l.dispatch(time.Now())
+		return ctx.Err()
+	}
+}
+
+// QueueDepth returns the number of callers currently waiting for a token.
+func (l *priorityLimiter) QueueDepth() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, q := range l.queues {
+		n += len(q)
+	}
+	return n
+}
+
+func (l *priorityLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	l.tokens = math.Min(l.burst, l.tokens+elapsed*l.rate)
+	l.sharedTokens = math.Min(l.sharedBurst, l.sharedTokens+elapsed*l.sharedRate)
+}
+
+// dispatch grants tokens to queued waiters in priority order and schedules
+// itself for when the next token becomes available. Callers hold l.mu.
+func (l *priorityLimiter) dispatch(now time.Time) {
+	l.refill(now)
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+
+	for {
+		w := l.head()
+		if w == nil {
+			return
+		}
+
+		wait := l.delay(w)
+		if wait > 0 {
+			l.timer = time.AfterFunc(wait, func() {
+				l.mu.Lock()
+				defer l.mu.Unlock()
+				l.dispatch(time.Now())
+			})
+			return
+		}
+
+		l.tokens--
+		if w.priority < PriorityHigh {
+			l.sharedTokens--
+		}
+		l.remove(w)
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+func (l *priorityLimiter) head() *limiterWaiter {
+	for i := len(l.queues) - 1; i >= 0; i-- {
+		if len(l.queues[i]) > 0 {
+			return l.queues[i][0]
+		}
+	}
+	return nil
+}
+
+func (l *priorityLimiter) delay(w *limiterWaiter) time.Duration {
+	wait := math.Max(0, 1-l.tokens) / l.rate
+	if w.priority < PriorityHigh {
+		if l.sharedRate <= 0 {
+			return time.Hour
+		}
+		wait = math.Max(wait, math.Max(0, 1-l.sharedTokens)/l.sharedRate)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(wait * float64(time.Second)))
+}
+
+func (l *priorityLimiter) remove(w *limiterWaiter) {
+	idx := queueIndex(w.priority)
+	q := l.queues[idx]
+	for i, queued := range q {
+		if queued == w {
+			l.queues[idx] = append(q[:i], q[i+1:]...)
+			return
+		}
+	}
+}
+
+func (l *priorityLimiter) refund(w *limiterWaiter) {
+	l.tokens = math.Min(l.burst, l.tokens+1)
+	if w.priority < PriorityHigh {
+		l.sharedTokens = math.Min(l.sharedBurst, l.sharedTokens+1)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func waitForQueueDepth(t *testing.T, l *priorityLimiter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for l.QueueDepth() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for queue depth %d", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPriorityLimiterServesHighPriorityFirst(t *testing.T) {
+	// One token every 250ms, so all four waiters are queued before the
+	// first is served.
+	l := newPriorityLimiter(240, 1, 0)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	wait := func(p Priority) {
+		defer wg.Done()
+		if err := l.Wait(WithPriority(context.Background(), p)); err != nil {
+			t.Error(err)
+			return
+		}
+		mu.Lock()
+		order = append(order, p)
+		mu.Unlock()
+	}
+
+	wg.Add(4)
+	go wait(PriorityLow)
+	waitForQueueDepth(t, l, 1)
+	go wait(PriorityNormal)
+	waitForQueueDepth(t, l, 2)
+	go wait(PriorityLow)
+	waitForQueueDepth(t, l, 3)
+	go wait(PriorityHigh)
+	waitForQueueDepth(t, l, 4)
+	wg.Wait()
+
+	want := []Priority{PriorityHigh, PriorityNormal, PriorityLow, PriorityLow}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("served in order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestPriorityLimiterZeroRateIsUnlimited(t *testing.T) {
+	l := newPriorityLimiter(0, 1, 0)
+	for i := 0; i < 100; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPriorityLimiterReservesCapacity(t *testing.T) {
+	l := newPriorityLimiter(60, 1, 0.5)
+	l.tokens, l.sharedTokens = 0, 0
+
+	high := l.delay(&limiterWaiter{priority: PriorityHigh})
+	low := l.delay(&limiterWaiter{priority: PriorityLow})
+	if high != time.Second {
+		t.Errorf("high priority delay = %v, want 1s", high)
+	}
+	if low != 2*time.Second {
+		t.Errorf("low priority delay = %v, want 2s", low)
+	}
+}
+
+func TestPriorityLimiterCancelledWaiterLeavesQueue(t *testing.T) {
+	l := newPriorityLimiter(1, 1, 0)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected wait to be cancelled")
+	}
+	if depth := l.QueueDepth(); depth != 0 {
+		t.Errorf("queue depth = %d after cancellation", depth)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces outgoing requests. Requests sharing a key, normally a
+// routing value such as "europe" or "euw1", share one limit.
+type RateLimiter interface {
+	Wait(ctx context.Context, key string) error
+}
+
+// MemoryRateLimiter is the default RateLimiter. It keeps one priority-aware
+// token bucket per key in process memory, so limits are only enforced within
+// a single Client.
+type MemoryRateLimiter struct {
+	mu             sync.RWMutex
+	limiters       map[string]*priorityLimiter
+	requestsPerMin int
+	burst          int
+	reserve        float64
+}
+
+func NewMemoryRateLimiter(requestsPerMin int, burst int, highPriorityReserve float64) *MemoryRateLimiter {
+	return &MemoryRateLimiter{
+		limiters:       make(map[string]*priorityLimiter),
+		requestsPerMin: requestsPerMin,
+		burst:          burst,
+		reserve:        highPriorityReserve,
+	}
+}
+
+func (m *MemoryRateLimiter) Wait(ctx context.Context, key string) error {
+	return m.limiter(key).Wait(ctx)
+}
+
+// QueueDepth returns the number of callers waiting on key.
+func (m *MemoryRateLimiter) QueueDepth(key string) int {
+	m.mu.RLock()
+	limiter, exists := m.limiters[key]
+	m.mu.RUnlock()
+
+	if !exists {
+		return 0
+	}
+	return limiter.QueueDepth()
+}
+
+func (m *MemoryRateLimiter) limiter(key string) *priorityLimiter {
+	m.mu.RLock()
+	limiter, exists := m.limiters[key]
+	m.mu.RUnlock()
+
+	if exists {
+		return limiter
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if limiter, exists := m.limiters[key]; exists {
+		return limiter
+	}
+	m.limiters[key] = newPriorityLimiter(m.requestsPerMin, m.burst, m.reserve)
+
+	return m.limiters[key]
+}
+
+// RateLimitWindow is one window of a Riot rate limit, e.g. 100 requests per
+// 120 seconds of which Count were used.
+type RateLimitWindow struct {
+	Limit int
+	Count int
+	Per   time.Duration
+}
+
+// ParseRateLimitHeaders combines Riot's "limit:seconds,..." and
+// "count:seconds,..." headers, such as X-App-Rate-Limit and
+// X-App-Rate-Limit-Count or their X-Method-Rate-Limit counterparts, into
+// windows. It returns nil if either header is empty.
+func ParseRateLimitHeaders(limits string, counts string) []RateLimitWindow {
+	if limits == "" || counts == "" {
+		return nil
+	}
+
+	used := make(map[string]int)
+	for _, part := range strings.Split(counts, ",") {
+		count, seconds, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(count); err == nil {
+			used[seconds] = n
+		}
+	}
+
+	var windows []RateLimitWindow
+	for _, part := range strings.Split(limits, ",") {
+		limit, seconds, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			continue
+		}
+		secs, err := strconv.Atoi(seconds)
+		if err != nil {
+			continue
+		}
+		windows = append(windows, RateLimitWindow{Limit: n, Count: used[seconds], Per: time.Duration(secs) * time.Second})
+	}
+	return windows
+}
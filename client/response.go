@@ -0,0 +1,45 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// Response carries a decoded value together with the exact response it was
+// decoded from, so the original bytes can be archived and decoded again later.
+type Response[T any] struct {
+	Value      T
+	Body       []byte
+	Header     http.Header
+	StatusCode int
+	FetchedAt  time.Time
+	FromCache  bool
+	// KeyName is the name of the API key the response was fetched with.
+	KeyName string
+	// DecodeWarnings lists the fields DecodeLenient zeroed. Struct values
+	// with a DecodeWarnings field get a copy; for other values, such as the
+	// slices of GetLeagueEntries, this is the only place they are reported.
+	DecodeWarnings []types.DecodeWarning
+}
+
+type rawResponse struct {
+	body       []byte
+	header     http.Header
+	statusCode int
+	fetchedAt  time.Time
+	fromCache  bool
+	keyName    string
+}
+
+func newResponse[T any](raw *rawResponse) *Response[T] {
+	return &Response[T]{
+		Body:       raw.body,
+		Header:     raw.header,
+		StatusCode: raw.statusCode,
+		FetchedAt:  raw.fetchedAt,
+		FromCache:  raw.fromCache,
+		KeyName:    raw.keyName,
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// result is the output of a command: data for JSON and YAML, and the same
+// data as rows for the table format.
+type result struct {
+	data   any
+	header []string
+	rows   [][]string
+}
+
+func oneArg(args []string, what string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("want exactly one %s, got %d arguments", what, len(args))
+	}
+	return args[0], nil
+}
+
+// resolvePUUID returns the PUUID of player, a Riot ID such as Player1#EUW or
+// a PUUID.
+func resolvePUUID(ctx context.Context, api client.API, region types.Region, player string) (types.PUUID, error) {
+	gameName, tagLine, ok := cutRiotID(player)
+	if !ok {
+		return types.NewPUUID(player), nil
+	}
+	account, err := api.GetAccountByRiotID(ctx, gameName, tagLine, region)
+	if err != nil {
+		return types.PUUID{}, fmt.Errorf("look up %s: %w", player, err)
+	}
+	return account.PUUID, nil
+}
+
+func cutRiotID(s string) (string, string, bool) {
+	i := strings.LastIndex(s, "#")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+func runAccount(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	player, err := oneArg(args, "player")
+	if err != nil {
+		return result{}, err
+	}
+	var account *types.Account
+	if gameName, tagLine, ok := cutRiotID(player); ok {
+		account, err = api.GetAccountByRiotID(ctx, gameName, tagLine, opts.region)
+	} else {
+		account, err = api.GetAccountByPUUID(ctx, types.NewPUUID(player), opts.region)
+	}
+	if err != nil {
+		return result{}, err
+	}
+	return result{
+		data:   account,
+		header: []string{"RIOT ID", "PUUID"},
+		rows:   [][]string{{account.GameName + "#" + account.TagLine, account.PUUID.Value}},
+	}, nil
+}
+
+func runSummoner(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	player, err := oneArg(args, "player")
+	if err != nil {
+		return result{}, err
+	}
+	puuid, err := resolvePUUID(ctx, api, opts.region, player)
+	if err != nil {
+		return result{}, err
+	}
+	summoner, err := api.GetSummonerByPUUID(ctx, puuid, opts.region)
+	if err != nil {
+		return result{}, err
+	}
+	return result{
+		data:   summoner,
+		header: []string{"PUUID", "LEVEL", "ICON", "REVISED"},
+		rows: [][]string{{
+			summoner.PUUID.Value,
+			strconv.Itoa(summoner.SummonerLevel),
+			strconv.Itoa(summoner.ProfileIconID),
+			formatMillis(summoner.RevisionDate),
+		}},
+	}, nil
+}
+
+func runMatches(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	player, err := oneArg(args, "player")
+	if err != nil {
+		return result{}, err
+	}
+	puuid, err := resolvePUUID(ctx, api, opts.region, player)
+	if err != nil {
+		return result{}, err
+	}
+	ids, err := api.GetMatchHistoryByPUUID(ctx, puuid, opts.region, opts.count)
+	if err != nil {
+		return result{}, err
+	}
+	rows := make([][]string, len(ids))
+	for i, id := range ids {
+		rows[i] = []string{id}
+	}
+	return result{data: ids, header: []string{"MATCH ID"}, rows: rows}, nil
+}
+
+func runMatch(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	matchID, err := oneArg(args, "match ID")
+	if err != nil {
+		return result{}, err
+	}
+	match, err := api.GetMatch(ctx, matchID, opts.region)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{
+		data:   match,
+		header: []string{"TEAM", "POSITION", "PLAYER", "CHAMPION", "K/D/A", "CS", "GOLD", "DAMAGE", "RESULT"},
+	}
+	for _, p := range match.Info.Participants {
+		outcome := "loss"
+		if p.Win {
+			outcome = "win"
+		}
+		res.rows = append(res.rows, []string{
+			teamName(p.TeamID),
+			p.TeamPosition,
+			p.RiotIDGameName + "#" + p.RiotIDTagline,
+			p.ChampionName,
+			fmt.Sprintf("%d/%d/%d", p.Kills, p.Deaths, p.Assists),
+			strconv.Itoa(p.TotalMinionsKilled + p.NeutralMinionsKilled),
+			strconv.Itoa(p.GoldEarned),
+			strconv.Itoa(p.TotalDamageDealtToChampions),
+			outcome,
+		})
+	}
+	return res, nil
+}
+
+func runTimeline(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	matchID, err := oneArg(args, "match ID")
+	if err != nil {
+		return result{}, err
+	}
+	timeline, err := api.GetMatchTimeline(ctx, matchID, opts.region)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{
+		data:   timeline,
+		header: []string{"TIME", "EVENTS", "BLUE GOLD", "RED GOLD", "GOLD DIFF"},
+	}
+	for _, frame := range timeline.Info.Frames {
+		// Participants 1 to 5 play on the blue side, 6 to 10 on the red side.
+		var blue, red int
+		for id, pf := range frame.Participants() {
+			if id <= 5 {
+				blue += pf.TotalGold
+			} else {
+				red += pf.TotalGold
+			}
+		}
+		res.rows = append(res.rows, []string{
+			formatGameTime(frame.Timestamp),
+			strconv.Itoa(len(frame.Events)),
+			strconv.Itoa(blue),
+			strconv.Itoa(red),
+			fmt.Sprintf("%+d", blue-red),
+		})
+	}
+	return res, nil
+}
+
+func runLeague(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	var entries []types.LeagueEntry
+	var data any
+	switch {
+	case len(args) == 1:
+		var league *types.LeagueList
+		var err error
+		switch strings.ToLower(args[0]) {
+		case "challenger":
+			league, err = api.GetChallengerLeague(ctx, opts.queue, opts.region)
+		case "grandmaster":
+			league, err = api.GetGrandMasterLeague(ctx, opts.queue, opts.region)
+		case "master":
+			league, err = api.GetMasterLeague(ctx, opts.queue, opts.region)
+		default:
+			return result{}, fmt.Errorf("unknown league %q, want challenger, grandmaster, master or a tier and division", args[0])
+		}
+		if err != nil {
+			return result{}, err
+		}
+		data, entries = league, league.Entries
+	case len(args) == 2:
+		var err error
+		entries, err = api.GetLeagueEntries(ctx, opts.queue, strings.ToUpper(args[0]), strings.ToUpper(args[1]), opts.region)
+		if err != nil {
+			return result{}, err
+		}
+		data = entries
+	default:
+		return result{}, fmt.Errorf("want a league or a tier and division, got %d arguments", len(args))
+	}
+
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b types.LeagueEntry) int { return b.LeaguePoints - a.LeaguePoints })
+	res := result{data: data, header: []string{"#", "PUUID", "LP", "WINS", "LOSSES", "WIN RATE"}}
+	for i, entry := range sorted {
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(i + 1),
+			entry.PUUID.Value,
+			strconv.Itoa(entry.LeaguePoints),
+			strconv.Itoa(entry.Wins),
+			strconv.Itoa(entry.Losses),
+			winRate(entry.Wins, entry.Losses),
+		})
+	}
+	return res, nil
+}
+
+func runRank(ctx context.Context, api client.API, opts *options, args []string) (result, error) {
+	player, err := oneArg(args, "player")
+	if err != nil {
+		return result{}, err
+	}
+	puuid, err := resolvePUUID(ctx, api, opts.region, player)
+	if err != nil {
+		return result{}, err
+	}
+	entries, err := api.GetLeagueEntriesByPUUID(ctx, puuid, opts.region)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{data: entries, header: []string{"QUEUE", "TIER", "RANK", "LP", "WINS", "LOSSES", "WIN RATE"}}
+	for _, entry := range entries {
+		res.rows = append(res.rows, []string{
+			entry.QueueType,
+			entry.Tier,
+			entry.Rank,
+			strconv.Itoa(entry.LeaguePoints),
+			strconv.Itoa(entry.Wins),
+			strconv.Itoa(entry.Losses),
+			winRate(entry.Wins, entry.Losses),
+		})
+	}
+	return res, nil
+}
+
+func teamName(teamID int) string {
+	switch teamID {
+	case 100:
+		return "blue"
+	case 200:
+		return "red"
+	}
+	return strconv.Itoa(teamID)
+}
+
+func winRate(wins int, losses int) string {
+	if wins+losses == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(wins)/float64(wins+losses))
+}
+
+func formatMillis(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).UTC().Format(time.DateTime)
+}
+
+func formatGameTime(ms int) string {
+	d := time.Duration(ms) * time.Millisecond
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// fileConfig is the config file. Flags override its defaults, and
+// RIOT_API_KEY overrides its key.
+type fileConfig struct {
+	APIKey string `yaml:"api_key"`
+	Region string `yaml:"region"`
+	Queue  string `yaml:"queue"`
+	Format string `yaml:"format"`
+}
+
+type options struct {
+	apiKey string
+	region types.Region
+	queue  string
+	format string
+	count  int
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "lolctl", "config.yaml")
+}
+
+// loadConfig reads the config file at path. A missing file is only an error
+// if the path was given explicitly.
+func loadConfig(path string, explicit bool) (fileConfig, error) {
+	var config fileConfig
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// parseOptions parses the flags of cmd, filling in defaults from the config
+// file and the environment, and returns the remaining arguments.
+func (c *cli) parseOptions(cmd *command, args []string) (*options, []string, error) {
+	// The config file provides flag defaults, so find it before parsing.
+	configPath, explicit := c.getenv("LOLCTL_CONFIG"), false
+	if configPath != "" {
+		explicit = true
+	} else {
+		configPath = defaultConfigPath()
+	}
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "config" {
+			continue
+		}
+		if !hasValue && i+1 < len(args) {
+			value = args[i+1]
+		}
+		configPath, explicit = value, true
+	}
+	config, err := loadConfig(configPath, explicit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	flags := flag.NewFlagSet("lolctl "+cmd.name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(c.stderr, "usage: lolctl %s [flags] %s\n\n%s.\n\nflags:\n", cmd.name, cmd.args, cmd.summary)
+		flags.PrintDefaults()
+	}
+	opts := &options{}
+	flags.String("config", configPath, "config file (env LOLCTL_CONFIG)")
+	region := flags.String("region", withDefault(config.Region, "euw1"), "platform, e.g. euw1, na1 or kr")
+	flags.StringVar(&opts.queue, "queue", withDefault(config.Queue, "RANKED_SOLO_5x5"), "ranked queue for league")
+	flags.StringVar(&opts.format, "format", withDefault(config.Format, "table"), "output format: table, json or yaml")
+	flags.IntVar(&opts.count, "count", 20, "number of match IDs for matches")
+	if err := flags.Parse(interleave(flags, args)); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.region.UnmarshalText([]byte(*region)); err != nil {
+		return nil, nil, err
+	}
+	switch opts.format {
+	case "table", "json", "yaml":
+	default:
+		return nil, nil, fmt.Errorf("unknown format %q, want table, json or yaml", opts.format)
+	}
+	opts.apiKey = withDefault(c.getenv("RIOT_API_KEY"), config.APIKey)
+	if opts.apiKey == "" {
+		return nil, nil, fmt.Errorf("no API key: set RIOT_API_KEY or api_key in %s", configPath)
+	}
+	return opts, flags.Args(), nil
+}
+
+// interleave moves flags after positional arguments to the front, so that
+// "lolctl match EUW1_1 -format json" works like the flag package expects.
+func interleave(set *flag.FlagSet, args []string) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		flags = append(flags, arg)
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := set.Lookup(name); f != nil && i+1 < len(args) {
+			if _, isBool := f.Value.(interface{ IsBoolFlag() bool }); !isBool {
+				i++
+				flags = append(flags, args[i])
+			}
+		}
+	}
+	return append(append(flags, "--"), positional...)
+}
+
+func withDefault(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
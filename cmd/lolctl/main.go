@@ -0,0 +1,121 @@
+// Command lolctl queries the Riot API from the command line.
+//
+//	lolctl account Faker#KR1 -region kr
+//	lolctl matches Player1#EUW -count 5
+//	lolctl match EUW1_7000000001 -format json
+//	lolctl league challenger -queue RANKED_FLEX_SR
+//	lolctl rank Player1#EUW -format yaml
+//
+// The API key is read from RIOT_API_KEY or from the api_key entry of the
+// config file, by default config.yaml in the lolctl directory of the user
+// config directory (e.g. ~/.config/lolctl/config.yaml). The config file can
+// also set defaults for region, queue and format.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/travior/lol-sdk/client"
+)
+
+// cli holds what commands depend on, so tests can replace the environment
+// and the HTTP client.
+type cli struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	getenv    func(string) string
+	newClient func(config client.Config) client.API
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, api client.API, opts *options, args []string) (result, error)
+}
+
+var commands = []command{
+	{"account", "<name#tag | puuid>", "show the Riot ID and PUUID of an account", runAccount},
+	{"summoner", "<name#tag | puuid>", "show a summoner", runSummoner},
+	{"matches", "<name#tag | puuid>", "list recent match IDs of a player", runMatches},
+	{"match", "<match id>", "show a match", runMatch},
+	{"timeline", "<match id>", "show the frames of a match timeline", runTimeline},
+	{"league", "<challenger | grandmaster | master | TIER DIVISION>", "list the players of a league", runLeague},
+	{"rank", "<name#tag | puuid>", "show the ranked entries of a player", runRank},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	c := &cli{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+		newClient: func(config client.Config) client.API {
+			return client.NewClient(config, nil)
+		},
+	}
+	err := c.run(ctx, os.Args[1:])
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(os.Stderr, "lolctl:", err)
+	}
+	os.Exit(exitCode(err))
+}
+
+// exitCode is 0 on success and when help was asked for, and 2 otherwise.
+func exitCode(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	return 2
+}
+
+func (c *cli) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		c.usage()
+		return errors.New("no command given")
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		c.usage()
+		return flag.ErrHelp
+	}
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == args[0] {
+			cmd = &commands[i]
+		}
+	}
+	if cmd == nil {
+		c.usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	opts, rest, err := c.parseOptions(cmd, args[1:])
+	if err != nil {
+		return err
+	}
+	api := c.newClient(client.Config{APIKey: opts.apiKey, MaxRetries: 3})
+	result, err := cmd.run(ctx, api, opts, rest)
+	if err != nil {
+		return err
+	}
+	return write(c.stdout, opts.format, result)
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: lolctl <command> [flags] [args]")
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stderr, "  %-9s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, `Run "lolctl <command> -h" for the flags of a command.`)
+}
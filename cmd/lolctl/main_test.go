@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/riottest"
+)
+
+// runCLI runs lolctl against the fixtures of a riottest server.
+func runCLI(t *testing.T, env map[string]string, args ...string) (string, error) {
+	t.Helper()
+	srv := riottest.NewServer()
+	t.Cleanup(srv.Close)
+
+	var stdout, stderr bytes.Buffer
+	c := &cli{
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(name string) string { return env[name] },
+		newClient: func(config client.Config) client.API {
+			config.HTTPClient = srv.HTTPClient()
+			return client.NewClient(config, nil)
+		},
+	}
+	err := c.run(context.Background(), args)
+	return stdout.String(), err
+}
+
+var keyEnv = map[string]string{"RIOT_API_KEY": "RGAPI-test", "LOLCTL_CONFIG": ""}
+
+func TestTableOutput(t *testing.T) {
+	out, err := runCLI(t, keyEnv, "account", "Player1#EUW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "RIOT ID      PUUID\nPlayer1#EUW  riottest-puuid-01\n"; out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+
+	out, err = runCLI(t, keyEnv, "matches", "Player1#EUW", "-count", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "MATCH ID\n" + riottest.FixtureMatchID + "\n"; out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+
+	out, err = runCLI(t, keyEnv, "match", riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 11 {
+		t.Errorf("expected a header and ten participants, got\n%s", out)
+	}
+
+	out, err = runCLI(t, keyEnv, "league", "challenger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(out), "\n"); len(lines) != 4 || !strings.HasPrefix(lines[1], "1 ") {
+		t.Errorf("expected three ranked challenger players, got\n%s", out)
+	}
+}
+
+func TestJSONAndYAMLOutput(t *testing.T) {
+	out, err := runCLI(t, keyEnv, "rank", "-format", "json", "Player8#EUW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []map[string]any
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(entries) != 1 || entries[0]["tier"] != "DIAMOND" {
+		t.Errorf("unexpected entries %v", entries)
+	}
+
+	out, err = runCLI(t, keyEnv, "account", "Player1#EUW", "-format=yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "puuid: riottest-puuid-01\ngameName: Player1\ntagLine: EUW\n"; out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("api_key: RGAPI-test\nformat: json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runCLI(t, nil, "matches", "-config", path, "-count", "2", riottest.FixturePUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	if err := json.Unmarshal([]byte(out), &ids); err != nil || len(ids) != 2 {
+		t.Errorf("expected two match IDs as JSON, got %q: %v", out, err)
+	}
+
+	if _, err := runCLI(t, map[string]string{"LOLCTL_CONFIG": filepath.Join(t.TempDir(), "missing.yaml")}, "account", "Player1#EUW"); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestHelpExitsZero(t *testing.T) {
+	for _, args := range [][]string{{"-h"}, {"help"}, {"match", "-h"}} {
+		_, err := runCLI(t, keyEnv, args...)
+		if code := exitCode(err); code != 0 {
+			t.Errorf("lolctl %s: exit code %d (%v), want 0", strings.Join(args, " "), code, err)
+		}
+	}
+	for _, args := range [][]string{{}, {"nope"}, {"match"}} {
+		_, err := runCLI(t, keyEnv, args...)
+		if code := exitCode(err); code != 2 {
+			t.Errorf("lolctl %s: exit code %d, want 2", strings.Join(args, " "), code)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// source is the part of *client.Client the fetcher uses.
+type source interface {
+	GetAccountByRiotID(ctx context.Context, gameName string, tagLine string, region types.Region) (*types.Account, error)
+	MatchIDs(ctx context.Context, puuid types.PUUID, region types.Region, opts client.MatchHistoryOptions) iter.Seq2[string, error]
+	GetMatchRaw(ctx context.Context, matchID string, region types.Region) (*client.Response[types.Match], error)
+	GetMatchTimelineRaw(ctx context.Context, matchID string, region types.Region) (*client.Response[types.MatchTimeline], error)
+}
+
+type fetcher struct {
+	api       source
+	region    types.Region
+	out       string
+	count     int
+	timelines bool
+	workers   int
+	// saveEvery is how many downloaded matches trigger a state save.
+	saveEvery int
+	state     *state
+	logger    *slog.Logger
+
+	downloaded atomic.Int64
+	failed     atomic.Int64
+}
+
+// run lists the histories of seeds and downloads their matches. Failed
+// matches stay pending for the next run; run returns an error if any
+// failed. The state is saved before returning, also when ctx is canceled.
+func (f *fetcher) run(ctx context.Context, seeds []string) error {
+	defer func() {
+		if err := f.state.save(); err != nil {
+			f.logger.Error("save state", "error", err)
+		}
+	}()
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, seed := range seeds {
+		history, err := f.history(ctx, seed)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			f.logger.Error("list match history", "seed", seed, "error", err)
+			f.failed.Add(1)
+			continue
+		}
+		for _, id := range history {
+			if !seen[id] && f.state.pending(id) {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if err := f.state.save(); err != nil {
+		return err
+	}
+	f.logger.Info("downloading matches", "pending", len(ids))
+
+	start := time.Now()
+	queue := make(chan string)
+	var wg sync.WaitGroup
+	for range max(f.workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range queue {
+				f.download(ctx, id)
+			}
+		}()
+	}
+	for _, id := range ids {
+		select {
+		case queue <- id:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	f.logger.Info("finished", "downloaded", f.downloaded.Load(), "failed", f.failed.Load(), "duration", time.Since(start).Round(time.Second))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if failed := f.failed.Load(); failed > 0 {
+		return fmt.Errorf("%d downloads failed; run again to retry them", failed)
+	}
+	return nil
+}
+
+// history returns up to f.count match IDs of seed, paging through its
+// history, or the IDs listed by an earlier run with the same count.
+func (f *fetcher) history(ctx context.Context, seed string) ([]string, error) {
+	if ids, ok := f.state.history(seed, f.count); ok {
+		return ids, nil
+	}
+	puuid := types.NewPUUID(seed)
+	if i := strings.LastIndex(seed, "#"); i >= 0 {
+		account, err := f.api.GetAccountByRiotID(ctx, seed[:i], seed[i+1:], f.region)
+		if err != nil {
+			return nil, err
+		}
+		puuid = account.PUUID
+	}
+	var ids []string
+	for id, err := range f.api.MatchIDs(ctx, puuid, f.region, client.MatchHistoryOptions{Limit: f.count}) {
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	f.state.setHistory(seed, f.count, ids)
+	f.logger.Info("listed match history", "seed", seed, "matches", len(ids))
+	return ids, nil
+}
+
+// download writes a match and, if enabled, its timeline. The match is only
+// marked done once all of its files are written.
+func (f *fetcher) download(ctx context.Context, matchID string) {
+	err := f.downloadFiles(ctx, matchID)
+	switch {
+	case err == nil:
+		f.state.markDone(matchID)
+		if n := f.downloaded.Add(1); f.saveEvery > 0 && n%int64(f.saveEvery) == 0 {
+			if err := f.state.save(); err != nil {
+				f.logger.Error("save state", "error", err)
+			}
+			f.logger.Info("progress", "downloaded", n)
+		}
+	case client.IsNotFound(err):
+		f.state.markMissing(matchID)
+		f.logger.Warn("match not found", "match", matchID)
+	case errors.Is(err, context.Canceled) && ctx.Err() != nil:
+	default:
+		f.failed.Add(1)
+		f.logger.Error("download match", "match", matchID, "error", err)
+	}
+}
+
+func (f *fetcher) downloadFiles(ctx context.Context, matchID string) error {
+	match, err := f.api.GetMatchRaw(ctx, matchID, f.region)
+	if err != nil {
+		return err
+	}
+	if err := writeGzip(filepath.Join(f.out, "matches", matchID+".json.gz"), match.Body); err != nil {
+		return err
+	}
+	if !f.timelines {
+		return nil
+	}
+	timeline, err := f.api.GetMatchTimelineRaw(ctx, matchID, f.region)
+	if err != nil {
+		return err
+	}
+	return writeGzip(filepath.Join(f.out, "timelines", matchID+".json.gz"), timeline.Body)
+}
+
+// writeGzip writes the response body unchanged, gzip-compressed.
+func writeGzip(path string, body []byte) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
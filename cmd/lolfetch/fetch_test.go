@@ -0,0 +1,197 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/types"
+)
+
+func newFetcher(t *testing.T, srv *riottest.Server, out string) *fetcher {
+	t.Helper()
+	for _, dir := range []string{"matches", "timelines"} {
+		if err := os.MkdirAll(filepath.Join(out, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	st, err := loadState(filepath.Join(out, "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &fetcher{
+		api:       client.NewClient(client.Config{APIKey: "RGAPI-test", HTTPClient: srv.HTTPClient()}, nil),
+		region:    riottest.FixtureRegion,
+		out:       out,
+		count:     20,
+		timelines: true,
+		workers:   2,
+		saveEvery: 1,
+		state:     st,
+		logger:    slog.New(slog.DiscardHandler),
+	}
+}
+
+func countRequests(srv *riottest.Server, endpoint string) int {
+	n := 0
+	for _, req := range srv.Requests() {
+		if req.Endpoint == endpoint {
+			n++
+		}
+	}
+	return n
+}
+
+func TestFetchWritesCompressedMatches(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	out := t.TempDir()
+
+	seeds := []string{"Player1#EUW", "riottest-puuid-06"}
+	if err := newFetcher(t, srv, out).run(context.Background(), seeds); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{"matches", "timelines"} {
+		for _, id := range []string{riottest.FixtureMatchID, riottest.FixtureOlderMatchID} {
+			file, err := os.Open(filepath.Join(out, dir, id+".json.gz"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			zr, err := gzip.NewReader(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var doc struct {
+				Metadata struct{ MatchID string } `json:"metadata"`
+			}
+			if err := json.NewDecoder(zr).Decode(&doc); err != nil {
+				t.Fatal(err)
+			}
+			file.Close()
+			if doc.Metadata.MatchID != id {
+				t.Errorf("%s/%s holds match %q", dir, id, doc.Metadata.MatchID)
+			}
+		}
+	}
+	if n := countRequests(srv, client.EndpointMatch); n != 2 {
+		t.Errorf("expected each shared match to be fetched once, got %d requests", n)
+	}
+
+	// A second run finds everything in the state and fetches nothing.
+	before := len(srv.Requests())
+	if err := newFetcher(t, srv, out).run(context.Background(), seeds); err != nil {
+		t.Fatal(err)
+	}
+	if after := len(srv.Requests()); after != before {
+		t.Errorf("resumed run made %d requests", after-before)
+	}
+}
+
+func TestFetchResumesFailedMatches(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	out := t.TempDir()
+	srv.Inject(riottest.Fault{Endpoint: client.EndpointMatchTimeline, Status: 500, Times: 1})
+
+	f := newFetcher(t, srv, out)
+	if err := f.run(context.Background(), []string{riottest.FixturePUUID}); err == nil {
+		t.Fatal("expected an error for the failed timeline")
+	}
+	if f.downloaded.Load() != 1 {
+		t.Fatalf("expected one complete match, got %d", f.downloaded.Load())
+	}
+
+	if err := newFetcher(t, srv, out).run(context.Background(), []string{riottest.FixturePUUID}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(srv, client.EndpointMatchIDsByPUUID); n != 1 {
+		t.Errorf("expected the history to be listed once, got %d", n)
+	}
+	if n := countRequests(srv, client.EndpointMatch); n != 3 {
+		t.Errorf("expected only the failed match to be fetched again, got %d match requests", n)
+	}
+
+	st, err := loadState(filepath.Join(out, "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Done[riottest.FixtureMatchID] || !st.Done[riottest.FixtureOlderMatchID] {
+		t.Errorf("state does not record both matches: %v", st.Done)
+	}
+}
+
+func TestFetchListsHistoryAgainWhenCountChanges(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	out := t.TempDir()
+
+	f := newFetcher(t, srv, out)
+	f.count = 1
+	if err := f.run(context.Background(), []string{riottest.FixturePUUID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := newFetcher(t, srv, out).run(context.Background(), []string{riottest.FixturePUUID}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(srv, client.EndpointMatchIDsByPUUID); n != 2 {
+		t.Errorf("expected the history to be listed again for the new count, got %d listings", n)
+	}
+	if n := countRequests(srv, client.EndpointMatch); n != 2 {
+		t.Errorf("expected each match to be fetched once, got %d match requests", n)
+	}
+}
+
+func TestFetchSkipsMissingMatches(t *testing.T) {
+	srv := riottest.NewEmptyServer()
+	defer srv.Close()
+	out := t.TempDir()
+	f := newFetcher(t, srv, out)
+	f.state.setHistory("seed", f.count, []string{"EUW1_1"})
+
+	if err := f.run(context.Background(), []string{"seed"}); err != nil {
+		t.Fatal(err)
+	}
+	if !f.state.Missing["EUW1_1"] {
+		t.Error("expected the match to be recorded as missing")
+	}
+	if _, err := os.Stat(filepath.Join(out, "matches", "EUW1_1.json.gz")); !os.IsNotExist(err) {
+		t.Errorf("expected no file for a missing match, got %v", err)
+	}
+}
+
+func TestFetchPagesThroughHistory(t *testing.T) {
+	srv := riottest.NewEmptyServer()
+	defer srv.Close()
+	for i := 0; i < 150; i++ {
+		var match types.Match
+		match.Metadata.MatchID = fmt.Sprintf("EUW1_%d", 1000+i)
+		match.Metadata.Participants = []types.PUUID{types.NewPUUID("seed")}
+		match.Info.GameStartTimestamp = int64(i)
+		if err := srv.AddMatch(match); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f := newFetcher(t, srv, t.TempDir())
+	f.count = 120
+
+	ids, err := f.history(context.Background(), "seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 120 {
+		t.Fatalf("got %d IDs, want 120", len(ids))
+	}
+	if ids[0] != "EUW1_1149" || ids[119] != "EUW1_1030" {
+		t.Errorf("got IDs from %s to %s, want the newest 120", ids[0], ids[119])
+	}
+	if n := countRequests(srv, client.EndpointMatchIDsByPUUID); n != 2 {
+		t.Errorf("expected two pages, got %d requests", n)
+	}
+}
@@ -0,0 +1,122 @@
+// Command lolfetch downloads the recent matches of a list of players into a
+// directory, as gzip-compressed JSON exactly as Riot returned it:
+//
+//	lolfetch -out data -timelines Faker#KR1 Chovy#KR1
+//	lolfetch -out data -seeds players.txt -region na1 -count 500
+//
+// Seeds are Riot IDs or PUUIDs, given as arguments or one per line in the
+// -seeds file. Matches are written to <out>/matches/<id>.json.gz and
+// timelines to <out>/timelines/<id>.json.gz. Progress is recorded in
+// <out>/state.json; running the same command again after an interruption
+// skips histories already listed and matches already downloaded.
+//
+// The API key is read from RIOT_API_KEY.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+func main() {
+	out := flag.String("out", "matches", "output directory")
+	seedsFile := flag.String("seeds", "", "file with one Riot ID or PUUID per line")
+	statePath := flag.String("state", "", "state file (default <out>/state.json)")
+	regionName := flag.String("region", "euw1", "platform of the seeds, e.g. euw1, na1 or kr")
+	count := flag.Int("count", 20, "matches per seed, fetched in pages of 100")
+	timelines := flag.Bool("timelines", false, "also download match timelines")
+	workers := flag.Int("workers", 4, "concurrent downloads")
+	requestsPerMin := flag.Int("rpm", 50, "requests per minute; 50 fits a development key")
+	flag.Parse()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := func() error {
+		var region types.Region
+		if err := region.UnmarshalText([]byte(*regionName)); err != nil {
+			return err
+		}
+		if *count < 1 {
+			return fmt.Errorf("-count must be at least 1")
+		}
+		apiKey := os.Getenv("RIOT_API_KEY")
+		if apiKey == "" {
+			return fmt.Errorf("RIOT_API_KEY is not set")
+		}
+		seeds, err := readSeeds(*seedsFile, flag.Args())
+		if err != nil {
+			return err
+		}
+		if len(seeds) == 0 {
+			return fmt.Errorf("no seeds given")
+		}
+
+		for _, dir := range []string{"matches", "timelines"} {
+			if err := os.MkdirAll(filepath.Join(*out, dir), 0o755); err != nil {
+				return err
+			}
+		}
+		if *statePath == "" {
+			*statePath = filepath.Join(*out, "state.json")
+		}
+		st, err := loadState(*statePath)
+		if err != nil {
+			return fmt.Errorf("load state: %w", err)
+		}
+
+		api := client.NewClient(client.Config{
+			APIKey:         apiKey,
+			RequestsPerMin: *requestsPerMin,
+			BurstSize:      10,
+			MaxRetries:     5,
+		}, logger)
+		f := &fetcher{
+			api:       api,
+			region:    region,
+			out:       *out,
+			count:     *count,
+			timelines: *timelines,
+			workers:   *workers,
+			saveEvery: 50,
+			state:     st,
+			logger:    logger,
+		}
+		return f.run(ctx, seeds)
+	}()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "lolfetch:", err)
+		os.Exit(1)
+	}
+}
+
+// readSeeds returns args followed by the non-empty lines of path.
+func readSeeds(path string, args []string) ([]string, error) {
+	seeds := append([]string(nil), args...)
+	if path == "" {
+		return seeds, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if seed := strings.TrimSpace(scanner.Text()); seed != "" {
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds, scanner.Err()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// state is the progress of a download, saved so an interrupted run resumes
+// where it stopped. A seed whose history is listed with the same -count and
+// a match whose files are written are never fetched again.
+type state struct {
+	path string
+	// saveMu is held from marshaling to renaming, so an older snapshot is
+	// never written over a newer one.
+	saveMu sync.Mutex
+
+	mu sync.Mutex
+	// Histories maps each seed to the match IDs listed for it.
+	Histories map[string]seedHistory `json:"histories"`
+	// Done holds the IDs of matches whose files were written.
+	Done map[string]bool `json:"done"`
+	// Missing holds the IDs of matches Riot answered with 404.
+	Missing map[string]bool `json:"missing,omitempty"`
+}
+
+func loadState(path string) (*state, error) {
+	s := &state{
+		path:      path,
+		Histories: make(map[string]seedHistory),
+		Done:      make(map[string]bool),
+		Missing:   make(map[string]bool),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// seedHistory is the match history listed for a seed.
+type seedHistory struct {
+	// Count is the -count the history was listed with.
+	Count int      `json:"count"`
+	IDs   []string `json:"ids"`
+}
+
+// history returns the history of seed listed with count, if there is one.
+func (s *state) history(seed string, count int) ([]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.Histories[seed]
+	if !ok || h.Count != count {
+		return nil, false
+	}
+	return h.IDs, true
+}
+
+func (s *state) setHistory(seed string, count int, ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Histories[seed] = seedHistory{Count: count, IDs: ids}
+}
+
+// pending reports whether a match still has to be downloaded.
+func (s *state) pending(matchID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.Done[matchID] && !s.Missing[matchID]
+}
+
+func (s *state) markDone(matchID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Done[matchID] = true
+}
+
+func (s *state) markMissing(matchID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Missing[matchID] = true
+}
+
+// save writes the state atomically, so a crash leaves the previous state.
+func (s *state) save() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	s.mu.Lock()
+	data, err := json.Marshal(s)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".lolfetch-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,434 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const header = "// Code generated by lolgen. DO NOT EDIT.\n\n"
+
+// Existing lists what is already written by hand in the target packages so
+// that lolgen does not generate it again.
+type Existing struct {
+	// Types are the names declared in the types package.
+	Types map[string]bool
+	// Endpoints are the endpoint names (operation IDs) declared as constants
+	// in the client package.
+	Endpoints map[string]bool
+}
+
+// initialisms are words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "id": true, "kda": true, "puuid": true, "url": true, "xp": true,
+}
+
+// goName converts a camelCase or dash-separated name to an exported Go name.
+func goName(s string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(word) > 0:
+			prevLower := unicode.IsLower(word[len(word)-1]) || unicode.IsDigit(word[len(word)-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(word[len(word)-1]) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	var b strings.Builder
+	for _, w := range words {
+		lower := strings.ToLower(w)
+		if initialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	for i, r := range s {
+		if !unicode.IsUpper(r) {
+			if i > 1 {
+				// Keep the last upper case letter of a leading initialism
+				// as the start of the next word, e.g. PUUIDScore.
+				i--
+			}
+			if i == 0 {
+				return s
+			}
+			return strings.ToLower(s[:i]) + s[i:]
+		}
+	}
+	return strings.ToLower(s)
+}
+
+// typeName derives the Go type name of a component schema, e.g.
+// "champion-mastery-v4.ChampionMasteryDto" becomes ChampionMastery.
+func typeName(name string, schema *Schema) string {
+	if schema != nil && schema.GoName != "" {
+		return schema.GoName
+	}
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "DTO"), "Dto")
+	return goName(name)
+}
+
+// idTypes maps property and parameter names holding encrypted IDs to their
+// types.
+var idTypes = map[string]string{
+	"puuid":               "PUUID",
+	"encryptedPUUID":      "PUUID",
+	"summonerId":          "SummonerID",
+	"encryptedSummonerId": "SummonerID",
+	"accountId":           "AccountID",
+	"encryptedAccountId":  "AccountID",
+}
+
+// idKinds names the ID types in key mismatch errors.
+var idKinds = map[string]string{
+	"PUUID":      "puuid",
+	"SummonerID": "summoner ID",
+	"AccountID":  "account ID",
+}
+
+type generator struct {
+	spec     *Spec
+	existing Existing
+}
+
+// goType returns the Go type of schema. qualifier is prepended to named
+// types, "types." when generating the client.
+func (g *generator) goType(name string, schema *Schema, qualifier string) (string, error) {
+	if schema.Ref != "" {
+		ref := refName(schema.Ref)
+		target, ok := g.spec.Components.Schemas[ref]
+		if !ok {
+			return "", fmt.Errorf("unknown schema %q", ref)
+		}
+		return qualifier + typeName(ref, target), nil
+	}
+	switch schema.Type {
+	case "string":
+		if id, ok := idTypes[name]; ok {
+			return qualifier + id, nil
+		}
+		return "string", nil
+	case "integer":
+		if schema.Format == "int64" {
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		if schema.Items == nil {
+			return "", fmt.Errorf("array %q without items", name)
+		}
+		elem, err := g.goType("", schema.Items, qualifier)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object":
+		if schema.AdditionalProperties != nil {
+			elem, err := g.goType("", schema.AdditionalProperties, qualifier)
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + elem, nil
+		}
+		return "map[string]any", nil
+	}
+	return "", fmt.Errorf("unsupported type %q of %q", schema.Type, name)
+}
+
+// GenerateTypes returns the types package file declaring every component
+// schema not already declared by hand.
+func GenerateTypes(spec *Spec, existing Existing) ([]byte, error) {
+	g := &generator{spec: spec, existing: existing}
+
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return typeName(names[i], spec.Components.Schemas[names[i]]) < typeName(names[j], spec.Components.Schemas[names[j]])
+	})
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package types\n")
+	for _, name := range names {
+		schema := spec.Components.Schemas[name]
+		declName := typeName(name, schema)
+		if existing.Types[declName] {
+			continue
+		}
+		if schema.Type != "object" {
+			return nil, fmt.Errorf("schema %q: only objects can be generated", name)
+		}
+
+		b.WriteString("\n")
+		writeComment(&b, "", schema.Description, declName+" is "+name+".")
+		fmt.Fprintf(&b, "type %s struct {\n", declName)
+		props := make([]string, 0, len(schema.Properties))
+		for prop := range schema.Properties {
+			props = append(props, prop)
+		}
+		slices.Sort(props)
+		for _, prop := range props {
+			typ, err := g.goType(prop, schema.Properties[prop], "")
+			if err != nil {
+				return nil, fmt.Errorf("schema %q: %w", name, err)
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:%q`\n", goName(prop), typ, prop)
+		}
+		b.WriteString("}\n")
+	}
+	return formatSource(&b)
+}
+
+type operation struct {
+	id       string
+	op       *Operation
+	path     string
+	routing  string
+	method   string
+	constant string
+	variable string
+}
+
+func (g *generator) operations() ([]operation, error) {
+	var ops []operation
+	for path, item := range g.spec.Paths {
+		if item.Get == nil || g.existing.Endpoints[item.Get.OperationID] {
+			continue
+		}
+		op := item.Get
+		service, opName, ok := strings.Cut(op.OperationID, ".")
+		if !ok {
+			return nil, fmt.Errorf("operation ID %q lacks a service prefix", op.OperationID)
+		}
+
+		routing := "RoutingPlatform"
+		switch {
+		case strings.HasPrefix(service, "account-"):
+			routing = "RoutingAccount"
+		case item.RouteEnum == "regional":
+			routing = "RoutingRegional"
+		}
+
+		method := op.GoName
+		if method == "" {
+			method = goName(opName)
+		}
+		base := strings.TrimPrefix(method, "Get")
+		ops = append(ops, operation{
+			id:       op.OperationID,
+			op:       op,
+			path:     path,
+			routing:  routing,
+			method:   method,
+			constant: "Endpoint" + base,
+			variable: lowerFirst(base),
+		})
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].method < ops[j].method })
+	return ops, nil
+}
+
+// GenerateClient returns the client package file defining and wrapping
+// every operation not already declared by hand.
+func GenerateClient(spec *Spec, existing Existing) ([]byte, error) {
+	g := &generator{spec: spec, existing: existing}
+	ops, err := g.operations()
+	if err != nil {
+		return nil, err
+	}
+
+	var consts, vars, methods bytes.Buffer
+	needQuery := false
+	for _, o := range ops {
+		responseSchema, err := o.op.response()
+		if err != nil {
+			return nil, err
+		}
+		response, err := g.goType("", responseSchema, "types.")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.id, err)
+		}
+
+		fmt.Fprintf(&consts, "\t%s = %q\n", o.constant, o.id)
+		fmt.Fprintf(&vars, "\t%s = Define[%s](EndpointDef{\n\t\tName: %s,\n\t\tPath: %q,\n\t\tRouting: %s,\n\t})\n",
+			o.variable, response, o.constant, o.path, o.routing)
+
+		var args, argNames, params, checks []string
+		var query []Parameter
+		for _, p := range o.op.Parameters {
+			switch p.In {
+			case "path":
+				arg := lowerFirst(goName(p.Name))
+				if token.IsKeyword(arg) {
+					arg += "Param"
+				}
+				typ := "string"
+				value := arg
+				if id, ok := idTypes[p.Name]; ok {
+					typ = "types." + id
+					value = arg + ".Value"
+					assign := ":="
+					if len(checks) > 0 {
+						assign = "="
+					}
+					checks = append(checks, fmt.Sprintf("ctx, err %s c.checkIDKey(ctx, %q, %s.EncryptedID)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n", assign, idKinds[id], arg))
+				}
+				args = append(args, arg+" "+typ)
+				argNames = append(argNames, arg)
+				params = append(params, fmt.Sprintf("%q: %s", p.Name, value))
+			case "query":
+				query = append(query, p)
+			}
+		}
+		args = append(args, "region types.Region")
+		argNames = append(argNames, "region")
+
+		optionsType := strings.TrimPrefix(o.method, "Get") + "Options"
+		queryArg := "nil"
+		if len(query) > 0 {
+			needQuery = true
+			queryArg = "query"
+			args = append(args, "opts *"+optionsType)
+			argNames = append(argNames, "opts")
+
+			methods.WriteString("\n")
+			writeComment(&methods, "", optionsType+" are the optional query parameters of "+o.method+".", "")
+			fmt.Fprintf(&methods, "type %s struct {\n", optionsType)
+			for _, p := range query {
+				typ, err := g.goType(p.Name, p.Schema, "types.")
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", o.id, err)
+				}
+				if p.Description != "" {
+					writeComment(&methods, "\t", p.Description, "")
+				}
+				fmt.Fprintf(&methods, "\t%s %s\n", goName(p.Name), typ)
+			}
+			methods.WriteString("}\n")
+		}
+
+		returnType, zero, value := response, "nil", "resp.Value"
+		switch {
+		case strings.HasPrefix(response, "types."):
+			returnType, value = "*"+response, "&resp.Value"
+		case response == "string":
+			zero = `""`
+		case response == "bool":
+			zero = "false"
+		case !strings.HasPrefix(response, "[]") && !strings.HasPrefix(response, "map["):
+			zero = "0"
+		}
+
+		methods.WriteString("\n")
+		writeComment(&methods, "", o.method+" calls "+o.id+". "+o.op.Summary, "")
+		fmt.Fprintf(&methods, "func (c *Client) %s(ctx context.Context, %s) (%s, error) {\n", o.method, strings.Join(args, ", "), returnType)
+		fmt.Fprintf(&methods, "\tresp, err := c.%sRaw(ctx, %s)\n\tif err != nil {\n\t\treturn %s, err\n\t}\n\treturn %s, nil\n}\n",
+			o.method, strings.Join(argNames, ", "), zero, value)
+
+		fmt.Fprintf(&methods, "\nfunc (c *Client) %sRaw(ctx context.Context, %s) (*Response[%s], error) {\n", o.method, strings.Join(args, ", "), response)
+		for _, check := range checks {
+			methods.WriteString("\t" + check)
+		}
+		if len(query) > 0 {
+			methods.WriteString("\tquery := url.Values{}\n\tif opts != nil {\n")
+			for _, p := range query {
+				field := "opts." + goName(p.Name)
+				typ, _ := g.goType(p.Name, p.Schema, "types.")
+				switch {
+				case strings.HasPrefix(typ, "[]"):
+					fmt.Fprintf(&methods, "\t\tfor _, v := range %s {\n\t\t\tquery.Add(%q, fmt.Sprint(v))\n\t\t}\n", field, p.Name)
+				case typ == "string":
+					fmt.Fprintf(&methods, "\t\tif %s != \"\" {\n\t\t\tquery.Set(%q, %s)\n\t\t}\n", field, p.Name, field)
+				case typ == "bool":
+					fmt.Fprintf(&methods, "\t\tif %s {\n\t\t\tquery.Set(%q, \"true\")\n\t\t}\n", field, p.Name)
+				default:
+					fmt.Fprintf(&methods, "\t\tif %s != 0 {\n\t\t\tquery.Set(%q, fmt.Sprint(%s))\n\t\t}\n", field, p.Name, field)
+				}
+			}
+			methods.WriteString("\t}\n")
+		}
+		fmt.Fprintf(&methods, "\treturn Call(ctx, c, %s, region, Params{%s}, %s)\n}\n", o.variable, strings.Join(params, ", "), queryArg)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(header)
+	b.WriteString("package client\n\nimport (\n\t\"context\"\n")
+	if needQuery {
+		b.WriteString("\t\"fmt\"\n\t\"net/url\"\n")
+	}
+	b.WriteString("\n\t\"github.com/travior/lol-sdk/types\"\n)\n")
+	if len(ops) > 0 {
+		fmt.Fprintf(&b, "\nconst (\n%s)\n\nvar (\n%s)\n", consts.String(), vars.String())
+	}
+	b.Write(methods.Bytes())
+	return formatSource(&b)
+}
+
+// writeComment writes text as a doc comment wrapped at 78 columns, or
+// fallback if text is empty. Line breaks in text are kept.
+func writeComment(b *bytes.Buffer, indent string, text string, fallback string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		text = fallback
+	}
+	if text == "" {
+		return
+	}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len(indent)+3+len(line)+1+len(word) > 78 {
+				fmt.Fprintf(b, "%s// %s\n", indent, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+func formatSource(b *bytes.Buffer) ([]byte, error) {
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w\n%s", err, b.String())
+	}
+	return src, nil
+}
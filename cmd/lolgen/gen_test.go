@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	spec, err := loadSpec(filepath.Join("testdata", "spec.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing := Existing{
+		Types:     map[string]bool{"Match": true},
+		Endpoints: map[string]bool{"match-v5.getMatch": true},
+	}
+
+	typesSrc, err := GenerateTypes(spec, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientSrc, err := GenerateClient(spec, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, got := range map[string][]byte{"types.golden": typesSrc, "client.golden": clientSrc} {
+		path := filepath.Join("testdata", name)
+		if *update {
+			if err := os.WriteFile(path, got, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s differs from generated code; run go test ./cmd/lolgen -update and review the diff\n%s", name, got)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	for in, want := range map[string]string{
+		"riotIdTagline":                  "RiotIDTagline",
+		"getAllChampionMasteriesByPUUID": "GetAllChampionMasteriesByPUUID",
+		"encryptedPUUID":                 "EncryptedPUUID",
+		"xpGain":                         "XPGain",
+		"by-queue":                       "ByQueue",
+		"championId":                     "ChampionID",
+	} {
+		if got := goName(in); got != want {
+			t.Errorf("goName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Command lolgen generates types and client methods from Riot's OpenAPI
+// specification. Schemas and endpoints already written by hand in the target
+// packages are skipped, so generated code only fills the gaps.
+//
+//	lolgen -spec spec/riot-openapi.json -types types/generated.go -client client/generated.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	specPath := flag.String("spec", "spec/riot-openapi.json", "OpenAPI JSON document")
+	typesOut := flag.String("types", "types/generated.go", "generated file in the types package")
+	clientOut := flag.String("client", "client/generated.go", "generated file in the client package")
+	flag.Parse()
+
+	if err := run(*specPath, *typesOut, *clientOut); err != nil {
+		fmt.Fprintln(os.Stderr, "lolgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath string, typesOut string, clientOut string) error {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	typeNames, _, err := declarations(filepath.Dir(typesOut), typesOut)
+	if err != nil {
+		return err
+	}
+	_, endpoints, err := declarations(filepath.Dir(clientOut), clientOut)
+	if err != nil {
+		return err
+	}
+	existing := Existing{Types: typeNames, Endpoints: endpoints}
+
+	typesSrc, err := GenerateTypes(spec, existing)
+	if err != nil {
+		return err
+	}
+	clientSrc, err := GenerateClient(spec, existing)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(typesOut, typesSrc, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(clientOut, clientSrc, 0o644)
+}
+
+// declarations returns the type names and string constant values declared
+// in the non-test Go files of dir, ignoring the file lolgen writes itself.
+func declarations(dir string, skip string) (map[string]bool, map[string]bool, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typeNames := make(map[string]bool)
+	constants := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if filepath.Clean(file) == filepath.Clean(skip) || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					typeNames[spec.Name.Name] = true
+				case *ast.ValueSpec:
+					if gen.Tok != token.CONST {
+						continue
+					}
+					for _, value := range spec.Values {
+						if lit, ok := value.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							if s, err := strconv.Unquote(lit.Value); err == nil {
+								constants[s] = true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return typeNames, constants, nil
+}
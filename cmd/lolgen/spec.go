@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3 document lolgen understands, including
+// the Riot specific x-route-enum extension.
+type Spec struct {
+	Paths      map[string]PathItem `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type PathItem struct {
+	Get *Operation `json:"get"`
+	// RouteEnum is "platform" or "regional".
+	RouteEnum string `json:"x-route-enum"`
+}
+
+type Operation struct {
+	OperationID string      `json:"operationId"`
+	Summary     string      `json:"summary"`
+	Parameters  []Parameter `json:"parameters"`
+	Responses   map[string]struct {
+		Content map[string]struct {
+			Schema *Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+	// GoName overrides the derived method name.
+	GoName string `json:"x-go-name"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Properties           map[string]*Schema `json:"properties"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	// GoName overrides the derived type name.
+	GoName string `json:"x-go-name"`
+}
+
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// response returns the schema of the 200 JSON response of op.
+func (op *Operation) response() (*Schema, error) {
+	ok, found := op.Responses["200"]
+	if !found {
+		return nil, fmt.Errorf("%s: no 200 response", op.OperationID)
+	}
+	content, found := ok.Content["application/json"]
+	if !found || content.Schema == nil {
+		return nil, fmt.Errorf("%s: no JSON response schema", op.OperationID)
+	}
+	return content.Schema, nil
+}
+
+// refName returns the schema name a "#/components/schemas/..." reference
+// points to.
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
@@ -0,0 +1,458 @@
+// Package crawler builds match datasets by snowballing from the ranked
+// ladders: it lists the challenger, grandmaster and master players of each
+// region, fetches their match histories, and expands breadth-first to every
+// participant of the matches found, up to a depth limit.
+//
+//	c := crawler.New(api, crawler.SinkFunc(save), crawler.Config{
+//		Regions:  []types.Region{types.EUW1, types.KR},
+//		QueueIDs: []int{420},
+//		MaxDepth: 2,
+//	}, logger)
+//	err := c.Run(ctx)
+//
+// Each region is crawled by its own pool of workers. All pools share the
+// client passed to New and with it its rate limiter, so the crawl goes as
+// fast as the API key allows.
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// API is the part of the client the crawler uses; *client.Client
+// implements it.
+type API interface {
+	client.LeagueAPI
+	client.MatchAPI
+	MatchIDs(ctx context.Context, puuid types.PUUID, region types.Region, opts client.MatchHistoryOptions) iter.Seq2[string, error]
+}
+
+// Sink receives each match the crawler accepts, exactly once. Put is
+// called concurrently from several workers. An error stops the crawl.
+type Sink interface {
+	Put(ctx context.Context, match *types.Match) error
+}
+
+// SinkFunc adapts a function to Sink.
+type SinkFunc func(ctx context.Context, match *types.Match) error
+
+func (f SinkFunc) Put(ctx context.Context, match *types.Match) error {
+	return f(ctx, match)
+}
+
+// League names a ladder to seed the crawl from.
+type League string
+
+const (
+	Challenger  League = "challenger"
+	Grandmaster League = "grandmaster"
+	Master      League = "master"
+)
+
+// Seed is a player to start from in addition to the ladders.
+type Seed struct {
+	Region types.Region
+	PUUID  types.PUUID
+}
+
+type Config struct {
+	// Regions are the platforms whose ladders seed the crawl. Players found
+	// in matches are crawled in the region they were found in, whose
+	// regional cluster serves their history.
+	Regions []types.Region
+	// Leagues are the ladders to seed from (default all three) and Queue
+	// their ranked queue (default RANKED_SOLO_5x5).
+	Leagues []League
+	Queue   string
+	Seeds   []Seed
+
+	// QueueIDs restricts the matches passed to the sink and expanded, e.g.
+	// 420 for ranked solo. Empty accepts every queue.
+	QueueIDs []int
+	// MinPatch and MaxPatch restrict them to patches within the range,
+	// inclusive; zero bounds are unbounded. A player's history is walked
+	// newest first and left at the first match older than MinPatch.
+	MinPatch types.GameVersion
+	MaxPatch types.GameVersion
+
+	// MaxDepth limits the expansion: seeds are at depth 0 and the
+	// participants of a depth-d player's matches at depth d+1. Players
+	// deeper than MaxDepth are not crawled.
+	MaxDepth int
+	// MatchesPerPlayer is the number of recent matches listed per player
+	// (default 20), in pages of 100.
+	MatchesPerPlayer int
+	// MaxMatches stops the crawl once that many matches were passed to the
+	// sink. Zero means no limit.
+	MaxMatches int
+	// Workers is the number of workers per region (default 4).
+	Workers int
+}
+
+// Stats counts what a crawl did so far.
+type Stats struct {
+	Players int
+	Matches int
+	// Filtered counts fetched matches rejected by QueueIDs or Patches.
+	Filtered int
+	Errors   int
+}
+
+type Crawler struct {
+	api    API
+	sink   Sink
+	config Config
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	players map[string]bool
+	// matches maps the IDs of claimed matches to their patch, which is
+	// zero until the match is fetched.
+	matches map[string]types.GameVersion
+	stats   Stats
+	queues  map[types.Region]*queue
+	// pending counts queued and running tasks; the crawl ends at zero.
+	pending int
+	err     error
+	cancel  context.CancelFunc
+}
+
+// New returns a crawler passing matches from api to sink. A nil logger
+// discards the crawler's log lines.
+func New(api API, sink Sink, config Config, logger *slog.Logger) *Crawler {
+	if len(config.Leagues) == 0 {
+		config.Leagues = []League{Challenger, Grandmaster, Master}
+	}
+	if config.Queue == "" {
+		config.Queue = "RANKED_SOLO_5x5"
+	}
+	if config.MatchesPerPlayer <= 0 {
+		config.MatchesPerPlayer = 20
+	}
+	if config.Workers <= 0 {
+		config.Workers = 4
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	return &Crawler{
+		api:     api,
+		sink:    sink,
+		config:  config,
+		logger:  logger,
+		players: make(map[string]bool),
+		matches: make(map[string]types.GameVersion),
+		queues:  make(map[types.Region]*queue),
+	}
+}
+
+// Stats returns the counts of the crawl so far.
+func (c *Crawler) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+type taskKind int
+
+const (
+	taskLeague taskKind = iota
+	taskPlayer
+	taskMatch
+)
+
+type task struct {
+	kind   taskKind
+	region types.Region
+	// id is the league, PUUID or match ID.
+	id    string
+	depth int
+}
+
+// Run crawls until no players within MaxDepth are left, MaxMatches is
+// reached or ctx is canceled. Failed API calls are logged and counted in
+// Stats; Run only fails if the sink does or ctx is canceled.
+func (c *Crawler) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c.mu.Lock()
+	c.cancel = cancel
+	for _, region := range c.config.Regions {
+		for _, league := range c.config.Leagues {
+			c.enqueueLocked(task{kind: taskLeague, region: region, id: string(league)})
+		}
+	}
+	for _, seed := range c.config.Seeds {
+		c.enqueuePlayerLocked(seed.Region, seed.PUUID.Value, 0)
+	}
+	done := c.pending == 0
+	c.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	go func() {
+		<-ctx.Done()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for _, q := range c.queues {
+			q.close()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, q := range c.regionQueues() {
+		for range c.config.Workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					t, ok := q.pop()
+					if !ok {
+						return
+					}
+					c.run(ctx, t)
+					c.finish()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	if c.config.MaxMatches > 0 && c.stats.Matches >= c.config.MaxMatches {
+		return nil
+	}
+	return context.Cause(ctx)
+}
+
+// regionQueues returns the queues of the regions of the initial tasks.
+// Players found later are crawled in the region of the player they were
+// found through, so no queues are added once workers run.
+func (c *Crawler) regionQueues() []*queue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	queues := make([]*queue, 0, len(c.queues))
+	for _, q := range c.queues {
+		queues = append(queues, q)
+	}
+	return queues
+}
+
+func (c *Crawler) enqueueLocked(t task) {
+	q, ok := c.queues[t.region]
+	if !ok {
+		q = newQueue()
+		c.queues[t.region] = q
+	}
+	c.pending++
+	q.push(t)
+}
+
+func (c *Crawler) enqueuePlayerLocked(region types.Region, puuid string, depth int) {
+	if depth > c.config.MaxDepth || c.players[puuid] {
+		return
+	}
+	c.players[puuid] = true
+	c.enqueueLocked(task{kind: taskPlayer, region: region, id: puuid, depth: depth})
+}
+
+// finish marks a task done and closes the queues after the last one.
+func (c *Crawler) finish() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending--
+	if c.pending == 0 {
+		for _, q := range c.queues {
+			q.close()
+		}
+	}
+}
+
+func (c *Crawler) run(ctx context.Context, t task) {
+	var err error
+	switch t.kind {
+	case taskLeague:
+		err = c.crawlLeague(ctx, t)
+	case taskPlayer:
+		err = c.crawlPlayer(ctx, t)
+	case taskMatch:
+		_, err = c.crawlMatch(ctx, t.region, t.id, t.depth)
+	}
+	if err != nil {
+		c.fail(ctx, t.region, t.id, err)
+	}
+}
+
+// fail counts and logs a failed API call, unless the crawl is stopping.
+func (c *Crawler) fail(ctx context.Context, region types.Region, id string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Errors++
+	c.logger.Warn("crawl failed", "region", region.ToString(), "id", id, "error", err)
+}
+
+func (c *Crawler) crawlLeague(ctx context.Context, t task) error {
+	var league *types.LeagueList
+	var err error
+	switch League(t.id) {
+	case Challenger:
+		league, err = c.api.GetChallengerLeague(ctx, c.config.Queue, t.region)
+	case Grandmaster:
+		league, err = c.api.GetGrandMasterLeague(ctx, c.config.Queue, t.region)
+	case Master:
+		league, err = c.api.GetMasterLeague(ctx, c.config.Queue, t.region)
+	default:
+		return fmt.Errorf("unknown league %q", t.id)
+	}
+	if err != nil {
+		return err
+	}
+	c.logger.Info("seeding from league", "region", t.region.ToString(), "league", t.id, "players", len(league.Entries))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range league.Entries {
+		c.enqueuePlayerLocked(t.region, entry.PUUID.Value, 0)
+	}
+	return nil
+}
+
+// crawlPlayer pages through the history of a player newest first. Matches
+// not seen before are queued to be fetched by any worker of the region. With
+// MinPatch set, the worker instead fetches them itself, one after another,
+// so it can stop at the first match older than MinPatch, since all further
+// matches are older still. That trades concurrency within one history for
+// not listing or fetching matches outside the patch range.
+func (c *Crawler) crawlPlayer(ctx context.Context, t task) (err error) {
+	defer func() {
+		if err == nil {
+			c.mu.Lock()
+			c.stats.Players++
+			c.mu.Unlock()
+		}
+	}()
+
+	opts := client.MatchHistoryOptions{Limit: c.config.MatchesPerPlayer}
+	for id, err := range c.api.MatchIDs(ctx, types.NewPUUID(t.id), t.region, opts) {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		c.mu.Lock()
+		patch, seen := c.matches[id]
+		if !seen {
+			// Claim the match; its patch is filled in once fetched.
+			c.matches[id] = types.GameVersion{}
+			if c.config.MinPatch.IsZero() {
+				c.enqueueLocked(task{kind: taskMatch, region: t.region, id: id, depth: t.depth})
+				c.mu.Unlock()
+				continue
+			}
+		}
+		c.mu.Unlock()
+
+		if !seen {
+			patch, err = c.crawlMatch(ctx, t.region, id, t.depth)
+			if err != nil {
+				c.fail(ctx, t.region, id, err)
+				continue
+			}
+		}
+		if c.belowMinPatch(patch) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// crawlMatch fetches a match, passes it to the sink if it is accepted and
+// queues its participants. It returns the patch of the match.
+func (c *Crawler) crawlMatch(ctx context.Context, region types.Region, id string, depth int) (types.GameVersion, error) {
+	match, err := c.api.GetMatch(ctx, id, region)
+	if client.IsNotFound(err) {
+		return types.GameVersion{}, nil
+	}
+	if err != nil {
+		return types.GameVersion{}, err
+	}
+	patch := match.Info.Patch()
+	c.mu.Lock()
+	c.matches[id] = patch
+	c.mu.Unlock()
+
+	if !c.accept(match) {
+		c.mu.Lock()
+		c.stats.Filtered++
+		c.mu.Unlock()
+		return patch, nil
+	}
+
+	c.mu.Lock()
+	if c.config.MaxMatches > 0 && c.stats.Matches >= c.config.MaxMatches {
+		c.mu.Unlock()
+		return patch, nil
+	}
+	// Count the match before calling the sink so concurrent workers do not
+	// overshoot MaxMatches.
+	c.stats.Matches++
+	limitReached := c.config.MaxMatches > 0 && c.stats.Matches >= c.config.MaxMatches
+	c.mu.Unlock()
+
+	if err := c.sink.Put(ctx, match); err != nil {
+		c.mu.Lock()
+		if c.err == nil {
+			c.err = fmt.Errorf("sink match %s: %w", id, err)
+		}
+		c.mu.Unlock()
+		c.cancel()
+		return patch, nil
+	}
+	if limitReached {
+		c.logger.Info("match limit reached", "matches", c.config.MaxMatches)
+		c.cancel()
+		return patch, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, puuid := range match.Metadata.Participants {
+		c.enqueuePlayerLocked(region, puuid.Value, depth+1)
+	}
+	return patch, nil
+}
+
+func (c *Crawler) belowMinPatch(patch types.GameVersion) bool {
+	return !c.config.MinPatch.IsZero() && !patch.IsZero() && patch.Before(c.config.MinPatch.Patch())
+}
+
+// accept applies the queue and patch filters.
+func (c *Crawler) accept(match *types.Match) bool {
+	if len(c.config.QueueIDs) > 0 && !slices.Contains(c.config.QueueIDs, match.Info.QueueID) {
+		return false
+	}
+	if c.config.MinPatch.IsZero() && c.config.MaxPatch.IsZero() {
+		return true
+	}
+	patch := match.Info.Patch()
+	return !patch.IsZero() && patch.InPatchRange(c.config.MinPatch, c.config.MaxPatch)
+}
@@ -0,0 +1,203 @@
+package crawler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/crawler"
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/types"
+)
+
+// collect is a sink remembering the IDs of the matches it received.
+type collect struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *collect) Put(ctx context.Context, match *types.Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append(s.ids, match.Metadata.MatchID)
+	return nil
+}
+
+func (s *collect) sorted() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Sorted(slices.Values(s.ids))
+}
+
+func newAPI(t *testing.T) (*client.Client, *riottest.Server) {
+	srv := riottest.NewServer()
+	t.Cleanup(srv.Close)
+	return client.NewClient(client.Config{APIKey: "RGAPI-test", HTTPClient: srv.HTTPClient()}, nil), srv
+}
+
+func TestCrawlFromLadder(t *testing.T) {
+	api, srv := newAPI(t)
+	sink := &collect{}
+	c := crawler.New(api, sink, crawler.Config{
+		Regions: []types.Region{riottest.FixtureRegion},
+		Leagues: []crawler.League{crawler.Challenger},
+	}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{riottest.FixtureMatchID, riottest.FixtureOlderMatchID}
+	if got := sink.sorted(); !slices.Equal(got, want) {
+		t.Errorf("got matches %v, want %v", got, want)
+	}
+	// Depth 0 only crawls the three challenger players.
+	if stats := c.Stats(); stats.Players != 3 || stats.Matches != 2 || stats.Errors != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	matchRequests := 0
+	for _, req := range srv.Requests() {
+		if req.Endpoint == client.EndpointMatch {
+			matchRequests++
+		}
+	}
+	if matchRequests != 2 {
+		t.Errorf("expected each match to be fetched once, got %d requests", matchRequests)
+	}
+}
+
+func TestCrawlExpandsParticipants(t *testing.T) {
+	api, _ := newAPI(t)
+	sink := &collect{}
+	c := crawler.New(api, sink, crawler.Config{
+		Seeds:    []crawler.Seed{{Region: riottest.FixtureRegion, PUUID: types.NewPUUID(riottest.FixturePUUID)}},
+		MaxDepth: 1,
+		Workers:  2,
+	}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stats := c.Stats(); stats.Players != 10 || stats.Matches != 2 {
+		t.Errorf("expected all ten participants to be crawled, got %+v", stats)
+	}
+}
+
+func TestCrawlFilters(t *testing.T) {
+	api, _ := newAPI(t)
+	seeds := []crawler.Seed{{Region: riottest.FixtureRegion, PUUID: types.NewPUUID(riottest.FixturePUUID)}}
+
+	sink := &collect{}
+	c := crawler.New(api, sink, crawler.Config{Seeds: seeds, MaxPatch: types.MustParseGameVersion("14.18")}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.sorted(); !slices.Equal(got, []string{riottest.FixtureOlderMatchID}) {
+		t.Errorf("patch filter passed %v", got)
+	}
+	if stats := c.Stats(); stats.Filtered != 1 {
+		t.Errorf("expected one filtered match, got %+v", stats)
+	}
+
+	sink = &collect{}
+	c = crawler.New(api, sink, crawler.Config{Seeds: seeds, QueueIDs: []int{440}}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.sorted(); len(got) != 0 {
+		t.Errorf("queue filter passed %v", got)
+	}
+}
+
+func TestCrawlStops(t *testing.T) {
+	api, _ := newAPI(t)
+	config := crawler.Config{Regions: []types.Region{riottest.FixtureRegion}, MaxDepth: 3, MaxMatches: 1}
+
+	sink := &collect{}
+	c := crawler.New(api, sink, config, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.sorted(); len(got) != 1 {
+		t.Errorf("expected the crawl to stop after one match, got %v", got)
+	}
+
+	boom := errors.New("disk full")
+	config.MaxMatches = 0
+	c = crawler.New(api, crawler.SinkFunc(func(ctx context.Context, match *types.Match) error {
+		return boom
+	}), config, nil)
+	if err := c.Run(context.Background()); !errors.Is(err, boom) {
+		t.Errorf("expected the sink error, got %v", err)
+	}
+}
+
+func TestCrawlStopsBelowMinPatch(t *testing.T) {
+	api, srv := newAPI(t)
+	oldest, err := riottest.FixtureMatch(riottest.FixtureOlderMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldest.Metadata.MatchID = "EUW1_7000000003"
+	oldest.Info.GameVersion = "14.17.600.1"
+	oldest.Info.GameStartTimestamp -= 14 * 24 * 3600 * 1000
+	if err := srv.AddMatch(oldest); err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &collect{}
+	c := crawler.New(api, sink, crawler.Config{
+		Seeds:    []crawler.Seed{{Region: riottest.FixtureRegion, PUUID: types.NewPUUID(riottest.FixturePUUID)}},
+		MinPatch: types.MustParseGameVersion("14.19"),
+	}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := sink.sorted(); !slices.Equal(got, []string{riottest.FixtureMatchID}) {
+		t.Errorf("got matches %v", got)
+	}
+	for _, req := range srv.Requests() {
+		if req.Endpoint == client.EndpointMatch && strings.HasSuffix(req.Path, "/"+oldest.Metadata.MatchID) {
+			t.Error("fetched a match after the first one below MinPatch")
+		}
+	}
+}
+
+func TestCrawlPagesThroughHistory(t *testing.T) {
+	api, srv := newAPI(t)
+	match, err := riottest.FixtureMatch(riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 150 {
+		match.Metadata.MatchID = fmt.Sprintf("EUW1_%d", 8000000000+i)
+		match.Info.GameStartTimestamp += 1000
+		if err := srv.AddMatch(match); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sink := &collect{}
+	c := crawler.New(api, sink, crawler.Config{
+		Seeds:            []crawler.Seed{{Region: riottest.FixtureRegion, PUUID: types.NewPUUID(riottest.FixturePUUID)}},
+		MatchesPerPlayer: 120,
+	}, nil)
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if stats := c.Stats(); stats.Players != 1 || stats.Matches != 120 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	pages := 0
+	for _, req := range srv.Requests() {
+		if req.Endpoint == client.EndpointMatchIDsByPUUID {
+			pages++
+		}
+	}
+	if pages != 2 {
+		t.Errorf("expected two pages, got %d requests", pages)
+	}
+}
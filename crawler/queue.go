@@ -0,0 +1,49 @@
+package crawler
+
+import "sync"
+
+// queue is an unbounded FIFO of tasks. Workers both take tasks from and add
+// tasks to it, so a bounded channel could deadlock.
+type queue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	tasks  []task
+	closed bool
+}
+
+func newQueue() *queue {
+	q := &queue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+func (q *queue) push(t task) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tasks = append(q.tasks, t)
+	q.cond.Signal()
+}
+
+// pop waits for a task. It returns false once the queue is closed, even if
+// tasks are left.
+func (q *queue) pop() (task, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.tasks) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return task{}, false
+	}
+	t := q.tasks[0]
+	q.tasks[0] = task{}
+	q.tasks = q.tasks[1:]
+	return t, true
+}
+
+func (q *queue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
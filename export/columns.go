@@ -0,0 +1,192 @@
+package export
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// Participants is the participant table. Its sets are:
+//
+//   - match: match_id, platform_id, queue_id, game_mode, patch,
+//     game_version, game_start (Unix milliseconds) and game_duration
+//     (seconds)
+//   - player: participant_id, puuid, riot_id_game_name, riot_id_tagline,
+//     team_id, team_position, champion_id, champion_name and win
+//   - combat: champ_level, kills, deaths, assists, largest_multi_kill,
+//     total_damage_dealt_to_champions, total_damage_taken,
+//     damage_self_mitigated and time_ccing_others
+//   - economy: gold_earned, gold_spent, total_minions_killed,
+//     neutral_minions_killed and champ_experience
+//   - vision: vision_score, wards_placed, wards_killed and
+//     detector_wards_placed
+//   - objectives: turret_kills, inhibitor_kills, dragon_kills, baron_kills,
+//     damage_dealt_to_buildings and damage_dealt_to_objectives
+//   - items: item0 to item6, summoner1_id and summoner2_id
+//
+// The default set is match, player, combat and economy.
+var Participants = newTable([]group[ParticipantRow]{
+	{"match", matchColumns(func(r ParticipantRow) *types.Match { return r.Match })},
+	{"player", participantColumns(
+		field("participant_id", func(p *types.Participant) any { return p.ParticipantID }),
+		field("puuid", func(p *types.Participant) any { return p.PUUID.Value }),
+		field("riot_id_game_name", func(p *types.Participant) any { return p.RiotIDGameName }),
+		field("riot_id_tagline", func(p *types.Participant) any { return p.RiotIDTagline }),
+		field("team_id", func(p *types.Participant) any { return p.TeamID }),
+		field("team_position", func(p *types.Participant) any { return p.TeamPosition }),
+		field("champion_id", func(p *types.Participant) any { return p.ChampionID }),
+		field("champion_name", func(p *types.Participant) any { return p.ChampionName }),
+		field("win", func(p *types.Participant) any { return p.Win }),
+	)},
+	{"combat", participantColumns(
+		field("champ_level", func(p *types.Participant) any { return p.ChampLevel }),
+		field("kills", func(p *types.Participant) any { return p.Kills }),
+		field("deaths", func(p *types.Participant) any { return p.Deaths }),
+		field("assists", func(p *types.Participant) any { return p.Assists }),
+		field("largest_multi_kill", func(p *types.Participant) any { return p.LargestMultiKill }),
+		field("total_damage_dealt_to_champions", func(p *types.Participant) any { return p.TotalDamageDealtToChampions }),
+		field("total_damage_taken", func(p *types.Participant) any { return p.TotalDamageTaken }),
+		field("damage_self_mitigated", func(p *types.Participant) any { return p.DamageSelfMitigated }),
+		field("time_ccing_others", func(p *types.Participant) any { return p.TimeCCingOthers }),
+	)},
+	{"economy", participantColumns(
+		field("gold_earned", func(p *types.Participant) any { return p.GoldEarned }),
+		field("gold_spent", func(p *types.Participant) any { return p.GoldSpent }),
+		field("total_minions_killed", func(p *types.Participant) any { return p.TotalMinionsKilled }),
+		field("neutral_minions_killed", func(p *types.Participant) any { return p.NeutralMinionsKilled }),
+		field("champ_experience", func(p *types.Participant) any { return p.ChampExperience }),
+	)},
+	{"vision", participantColumns(
+		field("vision_score", func(p *types.Participant) any { return p.VisionScore }),
+		field("wards_placed", func(p *types.Participant) any { return p.WardsPlaced }),
+		field("wards_killed", func(p *types.Participant) any { return p.WardsKilled }),
+		field("detector_wards_placed", func(p *types.Participant) any { return p.DetectorWardsPlaced }),
+	)},
+	{"objectives", participantColumns(
+		field("turret_kills", func(p *types.Participant) any { return p.TurretKills }),
+		field("inhibitor_kills", func(p *types.Participant) any { return p.InhibitorKills }),
+		field("dragon_kills", func(p *types.Participant) any { return p.DragonKills }),
+		field("baron_kills", func(p *types.Participant) any { return p.BaronKills }),
+		field("damage_dealt_to_buildings", func(p *types.Participant) any { return p.DamageDealtToBuildings }),
+		field("damage_dealt_to_objectives", func(p *types.Participant) any { return p.DamageDealtToObjectives }),
+	)},
+	{"items", participantColumns(
+		field("item0", func(p *types.Participant) any { return p.Item0 }),
+		field("item1", func(p *types.Participant) any { return p.Item1 }),
+		field("item2", func(p *types.Participant) any { return p.Item2 }),
+		field("item3", func(p *types.Participant) any { return p.Item3 }),
+		field("item4", func(p *types.Participant) any { return p.Item4 }),
+		field("item5", func(p *types.Participant) any { return p.Item5 }),
+		field("item6", func(p *types.Participant) any { return p.Item6 }),
+		field("summoner1_id", func(p *types.Participant) any { return p.Summoner1ID }),
+		field("summoner2_id", func(p *types.Participant) any { return p.Summoner2ID }),
+	)},
+}, "match", "player", "combat", "economy")
+
+// Teams is the team table. Its sets are:
+//
+//   - match: as for Participants
+//   - team: team_id and win
+//   - objectives: kills of and first flags for baron, champion, dragon,
+//     inhibitor, rift_herald and tower, e.g. dragon_kills and dragon_first
+//   - bans: ban1 to ban5, the banned champion IDs in pick order (0 if
+//     there was no ban)
+//
+// The default set is match, team and objectives.
+var Teams = newTable([]group[TeamRow]{
+	{"match", matchColumns(func(r TeamRow) *types.Match { return r.Match })},
+	{"team", []Column[TeamRow]{
+		{"team_id", func(r TeamRow) any { return r.Team.TeamID }},
+		{"win", func(r TeamRow) any { return r.Team.Win }},
+	}},
+	{"objectives", slices.Concat(
+		objectiveColumns("baron", func(o *types.TeamObjectives) types.TeamObjective { return o.Baron }),
+		objectiveColumns("champion", func(o *types.TeamObjectives) types.TeamObjective { return o.Champion }),
+		objectiveColumns("dragon", func(o *types.TeamObjectives) types.TeamObjective { return o.Dragon }),
+		objectiveColumns("inhibitor", func(o *types.TeamObjectives) types.TeamObjective { return o.Inhibitor }),
+		objectiveColumns("rift_herald", func(o *types.TeamObjectives) types.TeamObjective { return o.RiftHerald }),
+		objectiveColumns("tower", func(o *types.TeamObjectives) types.TeamObjective { return o.Tower }),
+	)},
+	{"bans", banColumns(5)},
+}, "match", "team", "objectives")
+
+type group[R any] struct {
+	name    string
+	columns []Column[R]
+}
+
+// newTable concatenates groups into a table with a set per group, an "all"
+// set and a "default" set of the named groups.
+func newTable[R any](groups []group[R], defaults ...string) Table[R] {
+	t := Table[R]{Sets: make(map[string][]string)}
+	for _, g := range groups {
+		for _, c := range g.columns {
+			t.Columns = append(t.Columns, c)
+			t.Sets[g.name] = append(t.Sets[g.name], c.Name)
+			t.Sets["all"] = append(t.Sets["all"], c.Name)
+		}
+	}
+	for _, name := range defaults {
+		t.Sets["default"] = append(t.Sets["default"], t.Sets[name]...)
+	}
+	return t
+}
+
+func matchColumns[R any](match func(R) *types.Match) []Column[R] {
+	return []Column[R]{
+		{"match_id", func(r R) any { return match(r).Metadata.MatchID }},
+		{"platform_id", func(r R) any { return match(r).Info.PlatformID }},
+		{"queue_id", func(r R) any { return match(r).Info.QueueID }},
+		{"game_mode", func(r R) any { return match(r).Info.GameMode }},
+		{"patch", func(r R) any {
+			patch := match(r).Info.Patch()
+			if patch.IsZero() {
+				return ""
+			}
+			return patch.String()
+		}},
+		{"game_version", func(r R) any { return match(r).Info.GameVersion }},
+		{"game_start", func(r R) any { return match(r).Info.GameStartTimestamp }},
+		{"game_duration", func(r R) any { return match(r).Info.GameDuration }},
+	}
+}
+
+type participantField struct {
+	name  string
+	value func(*types.Participant) any
+}
+
+func field(name string, value func(*types.Participant) any) participantField {
+	return participantField{name, value}
+}
+
+func participantColumns(fields ...participantField) []Column[ParticipantRow] {
+	columns := make([]Column[ParticipantRow], len(fields))
+	for i, f := range fields {
+		columns[i] = Column[ParticipantRow]{f.name, func(r ParticipantRow) any { return f.value(r.Participant) }}
+	}
+	return columns
+}
+
+func objectiveColumns(name string, objective func(*types.TeamObjectives) types.TeamObjective) []Column[TeamRow] {
+	return []Column[TeamRow]{
+		{name + "_kills", func(r TeamRow) any { return objective(&r.Team.Objectives).Kills }},
+		{name + "_first", func(r TeamRow) any { return objective(&r.Team.Objectives).First }},
+	}
+}
+
+func banColumns(n int) []Column[TeamRow] {
+	columns := make([]Column[TeamRow], n)
+	for i := range columns {
+		columns[i] = Column[TeamRow]{"ban" + strconv.Itoa(i+1), func(r TeamRow) any {
+			bans := slices.SortedFunc(slices.Values(r.Team.Bans), func(a, b types.TeamBan) int { return cmp.Compare(a.PickTurn, b.PickTurn) })
+			if i < len(bans) {
+				return bans[i].ChampionID
+			}
+			return 0
+		}}
+	}
+	return columns
+}
@@ -0,0 +1,131 @@
+// Package export writes matches as flat tables: one row per participant or
+// one row per team, as CSV or newline-delimited JSON.
+//
+//	columns, err := export.Participants.Select("default", "vision")
+//	...
+//	w := export.NewCSVWriter(file, columns)
+//	err = export.WriteParticipants(w, matches...)
+//
+// A Table lists its columns in a fixed order and names sets of them.
+// Selected columns are always written in table order, whatever order they
+// were selected in, so files written with the same selection line up.
+package export
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// ParticipantRow is one participant of a match.
+type ParticipantRow struct {
+	Match       *types.Match
+	Participant *types.Participant
+}
+
+// TeamRow is one team of a match.
+type TeamRow struct {
+	Match *types.Match
+	Team  *types.Team
+}
+
+// Column is a named value of a row. Value returns a string, an int, an
+// int64, a float64 or a bool.
+type Column[R any] struct {
+	Name  string
+	Value func(R) any
+}
+
+// Table is the set of columns available for rows of type R.
+type Table[R any] struct {
+	// Columns are all columns, in output order.
+	Columns []Column[R]
+	// Sets name groups of columns. Every table has a "default" and an
+	// "all" set.
+	Sets map[string][]string
+}
+
+// Select returns the columns named by names, each a column or set name, in
+// table order and without duplicates.
+func (t Table[R]) Select(names ...string) ([]Column[R], error) {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		if set, ok := t.Sets[name]; ok {
+			for _, column := range set {
+				selected[column] = true
+			}
+			continue
+		}
+		if !slices.ContainsFunc(t.Columns, func(c Column[R]) bool { return c.Name == name }) {
+			return nil, fmt.Errorf("export: unknown column or set %q", name)
+		}
+		selected[name] = true
+	}
+	var columns []Column[R]
+	for _, c := range t.Columns {
+		if selected[c.Name] {
+			columns = append(columns, c)
+		}
+	}
+	return columns, nil
+}
+
+// Default returns the columns of the "default" set.
+func (t Table[R]) Default() []Column[R] {
+	columns, _ := t.Select("default")
+	return columns
+}
+
+// ParticipantRows returns the participants of matches, in match order and
+// then by participant ID.
+func ParticipantRows(matches ...*types.Match) []ParticipantRow {
+	var rows []ParticipantRow
+	for _, match := range matches {
+		start := len(rows)
+		for i := range match.Info.Participants {
+			rows = append(rows, ParticipantRow{Match: match, Participant: &match.Info.Participants[i]})
+		}
+		slices.SortStableFunc(rows[start:], func(a, b ParticipantRow) int {
+			return cmp.Compare(a.Participant.ParticipantID, b.Participant.ParticipantID)
+		})
+	}
+	return rows
+}
+
+// TeamRows returns the teams of matches, in match order and then by team
+// ID.
+func TeamRows(matches ...*types.Match) []TeamRow {
+	var rows []TeamRow
+	for _, match := range matches {
+		start := len(rows)
+		for i := range match.Info.Teams {
+			rows = append(rows, TeamRow{Match: match, Team: &match.Info.Teams[i]})
+		}
+		slices.SortStableFunc(rows[start:], func(a, b TeamRow) int {
+			return cmp.Compare(a.Team.TeamID, b.Team.TeamID)
+		})
+	}
+	return rows
+}
+
+// WriteParticipants writes the participant rows of matches to w and flushes
+// it.
+func WriteParticipants(w RowWriter[ParticipantRow], matches ...*types.Match) error {
+	return writeRows(w, ParticipantRows(matches...))
+}
+
+// WriteTeams writes the team rows of matches to w and flushes it.
+func WriteTeams(w RowWriter[TeamRow], matches ...*types.Match) error {
+	return writeRows(w, TeamRows(matches...))
+}
+
+func writeRows[R any](w RowWriter[R], rows []R) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
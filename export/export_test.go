@@ -0,0 +1,138 @@
+package export_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/travior/lol-sdk/export"
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/types"
+)
+
+func fixtureMatches(t *testing.T) []*types.Match {
+	t.Helper()
+	var matches []*types.Match
+	for _, id := range []string{riottest.FixtureMatchID, riottest.FixtureOlderMatchID} {
+		match, err := riottest.FixtureMatch(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		matches = append(matches, &match)
+	}
+	return matches
+}
+
+func names[R any](columns []export.Column[R]) []string {
+	var names []string
+	for _, c := range columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func TestSelectKeepsTableOrder(t *testing.T) {
+	columns, err := export.Participants.Select("win", "kills", "match_id", "kills")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(columns), []string{"match_id", "win", "kills"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Select = %v, want %v", got, want)
+	}
+
+	columns, err = export.Participants.Select("vision", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := names(columns)
+	if got[0] != "match_id" || got[len(got)-1] != "detector_wards_placed" {
+		t.Errorf("Select(vision, default) = %v", got)
+	}
+
+	if _, err := export.Participants.Select("kills", "nope"); err == nil {
+		t.Error("Select of an unknown column succeeded")
+	}
+}
+
+func TestTableColumnNamesUnique(t *testing.T) {
+	for name, all := range map[string][]string{
+		"participants": names(export.Participants.Columns),
+		"teams":        names(export.Teams.Columns),
+	} {
+		seen := make(map[string]bool)
+		for _, column := range all {
+			if seen[column] {
+				t.Errorf("%s: duplicate column %q", name, column)
+			}
+			seen[column] = true
+		}
+	}
+}
+
+func TestParticipantsCSV(t *testing.T) {
+	columns, err := export.Participants.Select("match_id", "patch", "game_duration", "participant_id", "riot_id_game_name", "kills", "win")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := export.WriteParticipants(export.NewCSVWriter(&buf, columns), fixtureMatches(t)...); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 21 {
+		t.Fatalf("got %d records, want a header and 20 rows", len(records))
+	}
+	if want := []string{"match_id", "patch", "game_duration", "participant_id", "riot_id_game_name", "win", "kills"}; !reflect.DeepEqual(records[0], want) {
+		t.Errorf("header = %v, want %v", records[0], want)
+	}
+	if want := []string{riottest.FixtureMatchID, "14.19", "1805", "1", "Player1", "true", "5"}; !reflect.DeepEqual(records[1], want) {
+		t.Errorf("first row = %v, want %v", records[1], want)
+	}
+	if records[11][0] != riottest.FixtureOlderMatchID || records[11][1] != "14.18" {
+		t.Errorf("row 11 = %v, want the first participant of the older match", records[11])
+	}
+}
+
+func TestEmptyCSVHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := export.WriteTeams(export.NewCSVWriter(&buf, export.Teams.Default())); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "match_id,") || strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("output = %q, want only the header", buf.String())
+	}
+}
+
+func TestTeamsNDJSON(t *testing.T) {
+	columns, err := export.Teams.Select("team", "dragon_kills", "bans", "match_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := export.WriteTeams(export.NewNDJSONWriter(&buf, columns), fixtureMatches(t)[0]); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	want := `{"match_id":"EUW1_7000000001","team_id":100,"win":true,"dragon_kills":3,"ban1":55,"ban2":238,"ban3":11,"ban4":81,"ban5":350}`
+	if lines[0] != want {
+		t.Errorf("first line =\n%s\nwant\n%s", lines[0], want)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var row map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Errorf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+	}
+}
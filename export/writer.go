@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// RowWriter writes rows of type R. Rows may be buffered until Flush.
+type RowWriter[R any] interface {
+	Write(row R) error
+	Flush() error
+}
+
+// CSVWriter writes rows as CSV with a header line of column names.
+type CSVWriter[R any] struct {
+	w       *csv.Writer
+	columns []Column[R]
+	header  bool
+	record  []string
+}
+
+var _ RowWriter[ParticipantRow] = (*CSVWriter[ParticipantRow])(nil)
+
+// NewCSVWriter returns a CSVWriter writing columns to w. The header is
+// written with the first row, or by Flush if there are no rows.
+func NewCSVWriter[R any](w io.Writer, columns []Column[R]) *CSVWriter[R] {
+	return &CSVWriter[R]{w: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+}
+
+func (w *CSVWriter[R]) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	for i, c := range w.columns {
+		w.record[i] = c.Name
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *CSVWriter[R]) Write(row R) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	for i, c := range w.columns {
+		s, err := formatCSV(c.Value(row))
+		if err != nil {
+			return fmt.Errorf("export: column %s: %w", c.Name, err)
+		}
+		w.record[i] = s
+	}
+	return w.w.Write(w.record)
+}
+
+func (w *CSVWriter[R]) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func formatCSV(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", v)
+}
+
+// NDJSONWriter writes rows as newline-delimited JSON, one object per row
+// with keys in column order.
+type NDJSONWriter[R any] struct {
+	w       *bufio.Writer
+	columns []Column[R]
+	// keys holds the JSON-encoded column names.
+	keys [][]byte
+}
+
+var _ RowWriter[ParticipantRow] = (*NDJSONWriter[ParticipantRow])(nil)
+
+// NewNDJSONWriter returns an NDJSONWriter writing columns to w.
+func NewNDJSONWriter[R any](w io.Writer, columns []Column[R]) *NDJSONWriter[R] {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		keys[i], _ = json.Marshal(c.Name)
+	}
+	return &NDJSONWriter[R]{w: bufio.NewWriter(w), columns: columns, keys: keys}
+}
+
+func (w *NDJSONWriter[R]) Write(row R) error {
+	// An object is built by hand because encoding/json sorts map keys.
+	line := []byte{'{'}
+	for i, c := range w.columns {
+		value, err := json.Marshal(c.Value(row))
+		if err != nil {
+			return fmt.Errorf("export: column %s: %w", c.Name, err)
+		}
+		if i > 0 {
+			line = append(line, ',')
+		}
+		line = append(line, w.keys[i]...)
+		line = append(line, ':')
+		line = append(line, value...)
+	}
+	line = append(line, '}', '\n')
+	_, err := w.w.Write(line)
+	return err
+}
+
+func (w *NDJSONWriter[R]) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,357 @@
+// Package lolmock provides an in-memory client.API for unit testing code
+// built on the SDK without HTTP. Responses come from data added with
+// AddAccount, AddMatch and friends, can be replaced per method with the
+// *Func fields or SetError, and every call is recorded.
+//
+//	mock := lolmock.New()
+//	mock.AddMatch(match)
+//	mock.SetError("GetMatchTimeline", &client.APIError{StatusCode: 503})
+//	svc := NewService(mock) // takes a client.MatchAPI
+//	...
+//	if calls := mock.CallsTo("GetMatch"); len(calls) != 1 { ... }
+//
+// For tests that should exercise the client itself, such as rate limiting
+// and retries, use riottest instead.
+package lolmock
+
+import (
+	"cmp"
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// Call is a recorded method call. Args holds the arguments after the
+// context, in order.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Client implements client.API. The zero value is not usable; create one
+// with New. All methods are safe for concurrent use.
+//
+// A non-nil *Func field answers every call of its method instead of the
+// added data. Calls are recorded either way.
+type Client struct {
+	GetAccountByPUUIDFunc       func(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Account, error)
+	GetAccountByRiotIDFunc      func(ctx context.Context, gameName string, tagLine string, region types.Region) (*types.Account, error)
+	GetSummonerByPUUIDFunc      func(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Summoner, error)
+	GetMatchHistoryByPUUIDFunc  func(ctx context.Context, puuid types.PUUID, region types.Region, count int) ([]string, error)
+	GetMatchFunc                func(ctx context.Context, matchID string, region types.Region) (*types.Match, error)
+	GetMatchTimelineFunc        func(ctx context.Context, matchID string, region types.Region) (*types.MatchTimeline, error)
+	GetChallengerLeagueFunc     func(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetGrandMasterLeagueFunc    func(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetMasterLeagueFunc         func(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error)
+	GetLeagueEntriesFunc        func(ctx context.Context, queue string, tier string, division string, region types.Region) ([]types.LeagueEntry, error)
+	GetLeagueEntriesByPUUIDFunc func(ctx context.Context, puuid types.PUUID, region types.Region) ([]types.LeagueEntry, error)
+
+	mu        sync.Mutex
+	calls     []Call
+	errs      map[string]error
+	accounts  map[string]types.Account
+	riotIDs   map[string]string
+	summoners map[string]types.Summoner
+	matches   map[string]types.Match
+	timelines map[string]types.MatchTimeline
+	leagues   map[string]types.LeagueList
+	entries   map[string][]types.LeagueEntry
+}
+
+var _ client.API = (*Client)(nil)
+
+func New() *Client {
+	return &Client{
+		errs:      make(map[string]error),
+		accounts:  make(map[string]types.Account),
+		riotIDs:   make(map[string]string),
+		summoners: make(map[string]types.Summoner),
+		matches:   make(map[string]types.Match),
+		timelines: make(map[string]types.MatchTimeline),
+		leagues:   make(map[string]types.LeagueList),
+		entries:   make(map[string][]types.LeagueEntry),
+	}
+}
+
+// NotFound is the error returned for data that was not added. It satisfies
+// client.IsNotFound.
+func NotFound() error {
+	return &client.APIError{
+		StatusCode: http.StatusNotFound,
+		Body:       []byte(`{"status":{"message":"Data not found","status_code":404}}`),
+	}
+}
+
+// SetError makes every call of method, e.g. "GetMatch", fail with err. A
+// nil err removes the error again. It takes precedence over the *Func
+// fields.
+func (m *Client) SetError(method string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil {
+		delete(m.errs, method)
+		return
+	}
+	m.errs[method] = err
+}
+
+// Calls returns the calls made so far, in order.
+func (m *Client) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.calls)
+}
+
+// CallsTo returns the calls made so far to method, in order.
+func (m *Client) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls.
+func (m *Client) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// AddAccount adds an account, returned for every region.
+func (m *Client) AddAccount(account types.Account) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accounts[account.PUUID.Value] = account
+	m.riotIDs[riotIDKey(account.GameName, account.TagLine)] = account.PUUID.Value
+}
+
+func riotIDKey(gameName string, tagLine string) string {
+	return strings.ToLower(gameName + "#" + tagLine)
+}
+
+// AddSummoner adds a summoner on region.
+func (m *Client) AddSummoner(region types.Region, summoner types.Summoner) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.summoners[region.ToString()+"/"+summoner.PUUID.Value] = summoner
+}
+
+// AddMatch adds a match and lists it in the match history of each
+// participant, newest first. Matches are returned for every region.
+func (m *Client) AddMatch(match types.Match) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matches[match.Metadata.MatchID] = match
+}
+
+// AddTimeline adds the timeline of a match.
+func (m *Client) AddTimeline(timeline types.MatchTimeline) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timelines[timeline.Metadata.MatchID] = timeline
+}
+
+// SetLeague sets the challenger, grandmaster or master league of
+// league.Queue on region, chosen by league.Tier.
+func (m *Client) SetLeague(region types.Region, league types.LeagueList) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leagues[leagueKey(region.ToString(), league.Tier, league.Queue)] = league
+}
+
+func leagueKey(platform string, tier string, queue string) string {
+	return platform + "/" + strings.ToUpper(tier) + "/" + queue
+}
+
+// AddLeagueEntries adds ranked entries on region. Each entry needs
+// QueueType, Tier and Rank set.
+func (m *Client) AddLeagueEntries(region types.Region, entries ...types.LeagueEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range entries {
+		key := entriesKey(region.ToString(), entry.QueueType, entry.Tier, entry.Rank)
+		m.entries[key] = append(m.entries[key], entry)
+	}
+}
+
+func entriesKey(platform string, queue string, tier string, division string) string {
+	return platform + "/" + queue + "/" + strings.ToUpper(tier) + "/" + strings.ToUpper(division)
+}
+
+// record records a call and returns the error set for method, if any.
+func (m *Client) record(method string, args ...any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	return m.errs[method]
+}
+
+// lookup returns a copy of the value stored under key, or NotFound.
+func lookup[T any](m *Client, values map[string]T, key string) (*T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := values[key]
+	if !ok {
+		return nil, NotFound()
+	}
+	return &value, nil
+}
+
+func (m *Client) GetAccountByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Account, error) {
+	if err := m.record("GetAccountByPUUID", puuid, region); err != nil {
+		return nil, err
+	}
+	if m.GetAccountByPUUIDFunc != nil {
+		return m.GetAccountByPUUIDFunc(ctx, puuid, region)
+	}
+	return lookup(m, m.accounts, puuid.Value)
+}
+
+func (m *Client) GetAccountByRiotID(ctx context.Context, gameName string, tagLine string, region types.Region) (*types.Account, error) {
+	if err := m.record("GetAccountByRiotID", gameName, tagLine, region); err != nil {
+		return nil, err
+	}
+	if m.GetAccountByRiotIDFunc != nil {
+		return m.GetAccountByRiotIDFunc(ctx, gameName, tagLine, region)
+	}
+	m.mu.Lock()
+	puuid := m.riotIDs[riotIDKey(gameName, tagLine)]
+	m.mu.Unlock()
+	return lookup(m, m.accounts, puuid)
+}
+
+func (m *Client) GetSummonerByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) (*types.Summoner, error) {
+	if err := m.record("GetSummonerByPUUID", puuid, region); err != nil {
+		return nil, err
+	}
+	if m.GetSummonerByPUUIDFunc != nil {
+		return m.GetSummonerByPUUIDFunc(ctx, puuid, region)
+	}
+	return lookup(m, m.summoners, region.ToString()+"/"+puuid.Value)
+}
+
+func (m *Client) GetMatchHistoryByPUUID(ctx context.Context, puuid types.PUUID, region types.Region, count int) ([]string, error) {
+	if err := m.record("GetMatchHistoryByPUUID", puuid, region, count); err != nil {
+		return nil, err
+	}
+	if m.GetMatchHistoryByPUUIDFunc != nil {
+		return m.GetMatchHistoryByPUUIDFunc(ctx, puuid, region, count)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var history []types.Match
+	for _, match := range m.matches {
+		if slices.ContainsFunc(match.Metadata.Participants, func(p types.PUUID) bool { return p.Value == puuid.Value }) {
+			history = append(history, match)
+		}
+	}
+	slices.SortFunc(history, func(a, b types.Match) int {
+		if c := cmp.Compare(b.Info.GameStartTimestamp, a.Info.GameStartTimestamp); c != 0 {
+			return c
+		}
+		return strings.Compare(b.Metadata.MatchID, a.Metadata.MatchID)
+	})
+	ids := []string{}
+	for _, match := range history[:min(max(count, 0), len(history))] {
+		ids = append(ids, match.Metadata.MatchID)
+	}
+	return ids, nil
+}
+
+func (m *Client) GetMatch(ctx context.Context, matchID string, region types.Region) (*types.Match, error) {
+	if err := m.record("GetMatch", matchID, region); err != nil {
+		return nil, err
+	}
+	if m.GetMatchFunc != nil {
+		return m.GetMatchFunc(ctx, matchID, region)
+	}
+	return lookup(m, m.matches, matchID)
+}
+
+func (m *Client) GetMatchTimeline(ctx context.Context, matchID string, region types.Region) (*types.MatchTimeline, error) {
+	if err := m.record("GetMatchTimeline", matchID, region); err != nil {
+		return nil, err
+	}
+	if m.GetMatchTimelineFunc != nil {
+		return m.GetMatchTimelineFunc(ctx, matchID, region)
+	}
+	return lookup(m, m.timelines, matchID)
+}
+
+func (m *Client) GetChallengerLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
+	if err := m.record("GetChallengerLeague", queue, region); err != nil {
+		return nil, err
+	}
+	if m.GetChallengerLeagueFunc != nil {
+		return m.GetChallengerLeagueFunc(ctx, queue, region)
+	}
+	return lookup(m, m.leagues, leagueKey(region.ToString(), "CHALLENGER", queue))
+}
+
+func (m *Client) GetGrandMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
+	if err := m.record("GetGrandMasterLeague", queue, region); err != nil {
+		return nil, err
+	}
+	if m.GetGrandMasterLeagueFunc != nil {
+		return m.GetGrandMasterLeagueFunc(ctx, queue, region)
+	}
+	return lookup(m, m.leagues, leagueKey(region.ToString(), "GRANDMASTER", queue))
+}
+
+func (m *Client) GetMasterLeague(ctx context.Context, queue string, region types.Region) (*types.LeagueList, error) {
+	if err := m.record("GetMasterLeague", queue, region); err != nil {
+		return nil, err
+	}
+	if m.GetMasterLeagueFunc != nil {
+		return m.GetMasterLeagueFunc(ctx, queue, region)
+	}
+	return lookup(m, m.leagues, leagueKey(region.ToString(), "MASTER", queue))
+}
+
+func (m *Client) GetLeagueEntries(ctx context.Context, queue string, tier string, division string, region types.Region) ([]types.LeagueEntry, error) {
+	if err := m.record("GetLeagueEntries", queue, tier, division, region); err != nil {
+		return nil, err
+	}
+	if m.GetLeagueEntriesFunc != nil {
+		return m.GetLeagueEntriesFunc(ctx, queue, tier, division, region)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]types.LeagueEntry{}, m.entries[entriesKey(region.ToString(), queue, tier, division)]...), nil
+}
+
+func (m *Client) GetLeagueEntriesByPUUID(ctx context.Context, puuid types.PUUID, region types.Region) ([]types.LeagueEntry, error) {
+	if err := m.record("GetLeagueEntriesByPUUID", puuid, region); err != nil {
+		return nil, err
+	}
+	if m.GetLeagueEntriesByPUUIDFunc != nil {
+		return m.GetLeagueEntriesByPUUIDFunc(ctx, puuid, region)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prefix := region.ToString() + "/"
+	entries := []types.LeagueEntry{}
+	for key, list := range m.entries {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for _, entry := range list {
+			if entry.PUUID.Value == puuid.Value {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b types.LeagueEntry) int { return strings.Compare(a.QueueType, b.QueueType) })
+	return entries, nil
+}
@@ -0,0 +1,117 @@
+package lolmock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/lolmock"
+	"github.com/travior/lol-sdk/types"
+)
+
+// latestKills is the kind of consumer code the mock is for.
+func latestKills(ctx context.Context, api client.MatchAPI, puuid types.PUUID) (int, error) {
+	ids, err := api.GetMatchHistoryByPUUID(ctx, puuid, types.EUW1, 1)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	match, err := api.GetMatch(ctx, ids[0], types.EUW1)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range match.Info.Participants {
+		if p.PUUID.Value == puuid.Value {
+			return p.Kills, nil
+		}
+	}
+	return 0, nil
+}
+
+func newMatch(id string, start int64, kills int) types.Match {
+	var match types.Match
+	match.Metadata.MatchID = id
+	match.Metadata.Participants = []types.PUUID{types.NewPUUID("p1")}
+	match.Info.GameStartTimestamp = start
+	match.Info.Participants = []types.Participant{{PUUID: types.NewPUUID("p1"), Kills: kills}}
+	return match
+}
+
+func TestMockServesAddedData(t *testing.T) {
+	mock := lolmock.New()
+	mock.AddMatch(newMatch("EUW1_1", 100, 3))
+	mock.AddMatch(newMatch("EUW1_2", 200, 7))
+	ctx := context.Background()
+
+	kills, err := latestKills(ctx, mock, types.NewPUUID("p1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kills != 7 {
+		t.Errorf("expected kills of the newest match, got %d", kills)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 2 || calls[0].Method != "GetMatchHistoryByPUUID" || calls[1].Method != "GetMatch" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+	if calls[1].Args[0] != "EUW1_2" || calls[1].Args[1] != types.EUW1 {
+		t.Errorf("unexpected GetMatch args %v", calls[1].Args)
+	}
+
+	ids, err := mock.GetMatchHistoryByPUUID(ctx, types.NewPUUID("p1"), types.EUW1, -1)
+	if err != nil || len(ids) != 0 {
+		t.Errorf("history with a negative count = %v, %v, want none", ids, err)
+	}
+
+	if _, err := mock.GetMatchTimeline(ctx, "EUW1_2", types.EUW1); !client.IsNotFound(err) {
+		t.Errorf("expected not found for missing timeline, got %v", err)
+	}
+}
+
+func TestMockOverrides(t *testing.T) {
+	mock := lolmock.New()
+	ctx := context.Background()
+	boom := errors.New("boom")
+
+	mock.SetError("GetMatch", boom)
+	if _, err := mock.GetMatch(ctx, "EUW1_1", types.EUW1); !errors.Is(err, boom) {
+		t.Errorf("expected set error, got %v", err)
+	}
+	mock.SetError("GetMatch", nil)
+
+	mock.GetMatchFunc = func(ctx context.Context, matchID string, region types.Region) (*types.Match, error) {
+		match := newMatch(matchID, 0, 1)
+		return &match, nil
+	}
+	match, err := mock.GetMatch(ctx, "EUW1_9", types.EUW1)
+	if err != nil || match.Metadata.MatchID != "EUW1_9" {
+		t.Fatalf("func override not used: %+v, %v", match, err)
+	}
+	if got := len(mock.CallsTo("GetMatch")); got != 2 {
+		t.Errorf("expected 2 recorded GetMatch calls, got %d", got)
+	}
+}
+
+func TestMockLeagues(t *testing.T) {
+	mock := lolmock.New()
+	ctx := context.Background()
+	entry := types.LeagueEntry{QueueType: "RANKED_SOLO_5x5", Tier: "DIAMOND", Rank: "I", PUUID: types.NewPUUID("p1")}
+	mock.AddLeagueEntries(types.EUW1, entry)
+	mock.SetLeague(types.EUW1, types.LeagueList{Tier: "CHALLENGER", Queue: "RANKED_SOLO_5x5"})
+
+	if _, err := mock.GetChallengerLeague(ctx, "RANKED_SOLO_5x5", types.EUW1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mock.GetMasterLeague(ctx, "RANKED_SOLO_5x5", types.EUW1); !client.IsNotFound(err) {
+		t.Errorf("expected not found for unset league, got %v", err)
+	}
+	entries, err := mock.GetLeagueEntries(ctx, "RANKED_SOLO_5x5", "diamond", "i", types.EUW1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("unexpected entries %+v, %v", entries, err)
+	}
+	entries, err = mock.GetLeagueEntriesByPUUID(ctx, types.NewPUUID("p1"), types.KR)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("expected no entries on another region, got %+v, %v", entries, err)
+	}
+}
@@ -0,0 +1,213 @@
+// Package lolotel instruments a client.Client with OpenTelemetry traces and
+// metrics. Pass the Observer returned by New in client.Config.Observers.
+package lolotel
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/travior/lol-sdk/client"
+)
+
+const instrumentationName = "github.com/travior/lol-sdk/lolotel"
+
+var (
+	endpointKey     = attribute.Key("lol.endpoint")
+	routingValueKey = attribute.Key("lol.routing_value")
+	keyNameKey      = attribute.Key("lol.api_key")
+	attemptKey      = attribute.Key("lol.attempt")
+	retriesKey      = attribute.Key("lol.retries")
+	cacheHitKey     = attribute.Key("lol.cache_hit")
+	sharedKey       = attribute.Key("lol.shared")
+	statusCodeKey   = attribute.Key("http.response.status_code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider. The global one is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider sets the MeterProvider. The global one is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// Observer records a span per API call and a span per shared request, the
+// work done for all identical calls in flight, with a child span for the
+// time spent waiting on the rate limiter. It records the following metrics:
+//
+//   - lol.client.call.duration: call latency in seconds
+//   - lol.client.throttled: responses with status 429
+//   - lol.client.limiter.wait: rate limiter wait time in seconds
+//   - lol.client.cache.lookups: cache lookups, by hit
+//   - lol.client.cache.hit_ratio: share of cache lookups that were hits
+type Observer struct {
+	tracer trace.Tracer
+
+	callDuration metric.Float64Histogram
+	throttled    metric.Int64Counter
+	limiterWait  metric.Float64Histogram
+	cacheLookups metric.Int64Counter
+
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+}
+
+var _ client.Observer = (*Observer)(nil)
+
+func New(opts ...Option) (*Observer, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	o := &Observer{tracer: cfg.tracerProvider.Tracer(instrumentationName)}
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	var err error
+	if o.callDuration, err = meter.Float64Histogram("lol.client.call.duration",
+		metric.WithDescription("Duration of Riot API calls"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if o.throttled, err = meter.Int64Counter("lol.client.throttled",
+		metric.WithDescription("Responses rejected with status 429")); err != nil {
+		return nil, err
+	}
+	if o.limiterWait, err = meter.Float64Histogram("lol.client.limiter.wait",
+		metric.WithDescription("Time spent waiting on the rate limiter"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if o.cacheLookups, err = meter.Int64Counter("lol.client.cache.lookups",
+		metric.WithDescription("Response cache lookups")); err != nil {
+		return nil, err
+	}
+	if _, err = meter.Float64ObservableGauge("lol.client.cache.hit_ratio",
+		metric.WithDescription("Share of response cache lookups that were hits"),
+		metric.WithFloat64Callback(o.observeHitRatio)); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func requestAttributes(info client.RequestInfo) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		endpointKey.String(info.Endpoint),
+		routingValueKey.String(info.RoutingValue),
+	}
+}
+
+func (o *Observer) CallStart(ctx context.Context, info client.RequestInfo) context.Context {
+	attrs := requestAttributes(info)
+	for name, value := range info.PathParams {
+		attrs = append(attrs, attribute.String("lol.param."+name, value))
+	}
+	ctx, _ = o.tracer.Start(ctx, info.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return ctx
+}
+
+func (o *Observer) CallEnd(ctx context.Context, info client.RequestInfo, result client.CallResult) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(cacheHitKey.Bool(result.FromCache), sharedKey.Bool(result.Shared))
+	if result.Attempts > 0 {
+		span.SetAttributes(retriesKey.Int(result.Attempts - 1))
+	}
+	if result.StatusCode != 0 {
+		span.SetAttributes(statusCodeKey.Int(result.StatusCode))
+	}
+	if result.Err != nil {
+		span.RecordError(result.Err)
+		span.SetStatus(codes.Error, result.Err.Error())
+	}
+	span.End()
+
+	attrs := append(requestAttributes(info), statusCodeKey.Int(result.StatusCode))
+	o.callDuration.Record(ctx, result.Duration.Seconds(), metric.WithAttributes(attrs...))
+}
+
+func (o *Observer) CacheLookup(ctx context.Context, info client.RequestInfo, hit bool) {
+	if hit {
+		o.cacheHits.Add(1)
+	} else {
+		o.cacheMisses.Add(1)
+	}
+	o.cacheLookups.Add(ctx, 1, metric.WithAttributes(endpointKey.String(info.Endpoint), cacheHitKey.Bool(hit)))
+}
+
+func (o *Observer) LimiterWaitStart(ctx context.Context, info client.RequestInfo) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "rate limiter wait",
+		trace.WithAttributes(append(requestAttributes(info), keyNameKey.String(info.KeyName), attemptKey.Int(info.Attempt))...))
+	return ctx
+}
+
+func (o *Observer) LimiterWaitEnd(ctx context.Context, info client.RequestInfo, waited time.Duration, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	o.limiterWait.Record(ctx, waited.Seconds(), metric.WithAttributes(routingValueKey.String(info.RoutingValue), keyNameKey.String(info.KeyName)))
+}
+
+func (o *Observer) AttemptEnd(ctx context.Context, info client.RequestInfo, result client.AttemptResult) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent("attempt", trace.WithAttributes(
+		attemptKey.Int(info.Attempt),
+		keyNameKey.String(info.KeyName),
+		statusCodeKey.Int(result.StatusCode),
+	))
+	span.SetAttributes(keyNameKey.String(info.KeyName))
+
+	if result.StatusCode == 429 {
+		o.throttled.Add(ctx, 1, metric.WithAttributes(requestAttributes(info)...))
+	}
+}
+
+// FlightStart starts a new trace for the work shared by identical calls,
+// linked to the call that started it, so that work is not cut short by
+// that caller's span ending.
+func (o *Observer) FlightStart(ctx context.Context, info client.RequestInfo) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "shared request",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.LinkFromContext(ctx)),
+		trace.WithAttributes(requestAttributes(info)...))
+	return ctx
+}
+
+func (o *Observer) FlightEnd(ctx context.Context, info client.RequestInfo, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (o *Observer) observeHitRatio(ctx context.Context, observer metric.Float64Observer) error {
+	hits, misses := o.cacheHits.Load(), o.cacheMisses.Load()
+	if hits+misses == 0 {
+		return nil
+	}
+	observer.Observe(float64(hits) / float64(hits+misses))
+	return nil
+}
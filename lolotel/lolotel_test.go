@@ -0,0 +1,135 @@
+package lolotel
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// fakeRiot answers every request itself instead of calling Riot, throttling
+// the first one.
+func fakeRiot(next client.Doer) client.Doer {
+	calls := 0
+	return client.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": {"0"}},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"metadata":{"matchId":"EUW1_1"}}`)),
+		}, nil
+	})
+}
+
+func TestObserverRecordsSpansAndMetrics(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	observer, err := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := client.NewClient(client.Config{
+		APIKey:         "RGAPI-test",
+		RequestsPerMin: 6000,
+		MaxRetries:     1,
+		Cache:          client.NewMemoryCache(10),
+		Middleware:     []client.RequestMiddleware{fakeRiot},
+		Observers:      []client.Observer{observer},
+	}, nil)
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetMatch(ctx, "EUW1_1", types.EUW1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var calls, flights, waits []sdktrace.ReadOnlySpan
+	for _, span := range spans.Ended() {
+		switch span.Name() {
+		case client.EndpointMatch:
+			calls = append(calls, span)
+		case "shared request":
+			flights = append(flights, span)
+		case "rate limiter wait":
+			waits = append(waits, span)
+		}
+	}
+	if len(calls) != 2 || len(flights) != 2 || len(waits) != 2 {
+		t.Fatalf("got %d call, %d shared request and %d limiter spans, want 2, 2 and 2", len(calls), len(flights), len(waits))
+	}
+	for i, flight := range flights {
+		if flight.Parent().IsValid() || flight.SpanContext().TraceID() == calls[i].SpanContext().TraceID() {
+			t.Errorf("shared request %d is part of its caller's trace", i)
+		}
+		if links := flight.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != calls[i].SpanContext().SpanID() {
+			t.Errorf("shared request %d is not linked to its call span", i)
+		}
+	}
+	for _, wait := range waits {
+		if wait.Parent().SpanID() != flights[0].SpanContext().SpanID() {
+			t.Error("limiter span is not a child of the first shared request span")
+		}
+	}
+	if got := attributeValue(calls[0].Attributes(), retriesKey); got != attribute.IntValue(1) {
+		t.Errorf("retries = %v, want 1", got.Emit())
+	}
+	if got := attributeValue(calls[1].Attributes(), cacheHitKey); got != attribute.BoolValue(true) {
+		t.Errorf("second call cache_hit = %v, want true", got.Emit())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := map[string]metricdata.Aggregation{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	throttled, ok := metrics["lol.client.throttled"].(metricdata.Sum[int64])
+	if !ok || len(throttled.DataPoints) != 1 || throttled.DataPoints[0].Value != 1 {
+		t.Errorf("unexpected throttled metric %+v", metrics["lol.client.throttled"])
+	}
+	ratio, ok := metrics["lol.client.cache.hit_ratio"].(metricdata.Gauge[float64])
+	if !ok || len(ratio.DataPoints) != 1 || ratio.DataPoints[0].Value != 0.5 {
+		t.Errorf("unexpected hit ratio %+v", metrics["lol.client.cache.hit_ratio"])
+	}
+	for _, name := range []string{"lol.client.call.duration", "lol.client.limiter.wait", "lol.client.cache.lookups"} {
+		if _, ok := metrics[name]; !ok {
+			t.Errorf("metric %s was not recorded", name)
+		}
+	}
+}
+
+func attributeValue(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return attribute.Value{}
+}
@@ -0,0 +1,148 @@
+// Package lolprom exposes Prometheus metrics for a client.Client. A Collector
+// is both a client.Observer, to be passed in client.Config.Observers, and a
+// prometheus.Collector, to be registered on any prometheus.Registerer.
+package lolprom
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/travior/lol-sdk/client"
+)
+
+// Collector records:
+//
+//   - lol_client_requests_total{endpoint,routing_value}
+//   - lol_client_errors_total{endpoint,routing_value,status}
+//   - lol_client_request_duration_seconds{endpoint,routing_value}
+//   - lol_client_rate_limit_headroom{routing_value,api_key,scope,endpoint,window_seconds},
+//     the requests left in each window of Riot's app and method rate limits as
+//     reported by the last response. Method limits are per endpoint, so
+//     endpoint is set for scope="method" and empty for scope="app".
+//   - lol_client_limiter_queue_depth{routing_value}, the calls currently
+//     waiting on the rate limiter
+type Collector struct {
+	client.NopObserver
+
+	requests   *prometheus.CounterVec
+	errors     *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	headroom   *prometheus.GaugeVec
+	queueDepth *prometheus.GaugeVec
+}
+
+var (
+	_ client.Observer      = (*Collector)(nil)
+	_ prometheus.Collector = (*Collector)(nil)
+)
+
+type Options struct {
+	// Namespace prefixes every metric name. It defaults to "lol".
+	Namespace string
+	// Buckets for the request duration histogram. They default to
+	// prometheus.DefBuckets.
+	Buckets []float64
+}
+
+func New(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "lol"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Subsystem: "client",
+			Name:      "requests_total",
+			Help:      "Riot API calls made.",
+		}, []string{"endpoint", "routing_value"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: opts.Namespace,
+			Subsystem: "client",
+			Name:      "errors_total",
+			Help:      "Riot API calls that failed, by HTTP status.",
+		}, []string{"endpoint", "routing_value", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: opts.Namespace,
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Riot API calls including rate limiter waits and retries.",
+			Buckets:   opts.Buckets,
+		}, []string{"endpoint", "routing_value"}),
+		headroom: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: opts.Namespace,
+			Subsystem: "client",
+			Name:      "rate_limit_headroom",
+			Help:      "Requests left in a Riot rate-limit window as of the last response.",
+		}, []string{"routing_value", "api_key", "scope", "endpoint", "window_seconds"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: opts.Namespace,
+			Subsystem: "client",
+			Name:      "limiter_queue_depth",
+			Help:      "Calls currently waiting on the rate limiter.",
+		}, []string{"routing_value"}),
+	}
+}
+
+// Register registers the collector on reg.
+func (c *Collector) Register(reg prometheus.Registerer) error {
+	return reg.Register(c)
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.duration.Describe(ch)
+	c.headroom.Describe(ch)
+	c.queueDepth.Describe(ch)
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.duration.Collect(ch)
+	c.headroom.Collect(ch)
+	c.queueDepth.Collect(ch)
+}
+
+func (c *Collector) CallEnd(ctx context.Context, info client.RequestInfo, result client.CallResult) {
+	c.requests.WithLabelValues(info.Endpoint, info.RoutingValue).Inc()
+	c.duration.WithLabelValues(info.Endpoint, info.RoutingValue).Observe(result.Duration.Seconds())
+	if result.Err != nil {
+		status := "none"
+		if result.StatusCode != 0 {
+			status = strconv.Itoa(result.StatusCode)
+		}
+		c.errors.WithLabelValues(info.Endpoint, info.RoutingValue, status).Inc()
+	}
+}
+
+func (c *Collector) LimiterWaitStart(ctx context.Context, info client.RequestInfo) context.Context {
+	c.queueDepth.WithLabelValues(info.RoutingValue).Inc()
+	return ctx
+}
+
+func (c *Collector) LimiterWaitEnd(ctx context.Context, info client.RequestInfo, waited time.Duration, err error) {
+	c.queueDepth.WithLabelValues(info.RoutingValue).Dec()
+}
+
+func (c *Collector) AttemptEnd(ctx context.Context, info client.RequestInfo, result client.AttemptResult) {
+	if result.Header == nil {
+		return
+	}
+	c.recordHeadroom(info, "app", "", result.Header.Get("X-App-Rate-Limit"), result.Header.Get("X-App-Rate-Limit-Count"))
+	c.recordHeadroom(info, "method", info.Endpoint, result.Header.Get("X-Method-Rate-Limit"), result.Header.Get("X-Method-Rate-Limit-Count"))
+}
+
+func (c *Collector) recordHeadroom(info client.RequestInfo, scope string, endpoint string, limits string, counts string) {
+	for _, w := range client.ParseRateLimitHeaders(limits, counts) {
+		window := strconv.Itoa(int(w.Per / time.Second))
+		c.headroom.WithLabelValues(info.RoutingValue, info.KeyName, scope, endpoint, window).Set(float64(w.Limit - w.Count))
+	}
+}
@@ -0,0 +1,87 @@
+package lolprom
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/types"
+)
+
+// fakeRiot answers requests itself: match EUW1_404 is missing and every
+// response carries rate-limit headers.
+func fakeRiot(next client.Doer) client.Doer {
+	return client.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{
+			"X-App-Rate-Limit":          {"20:1,100:120"},
+			"X-App-Rate-Limit-Count":    {"1:1,7:120"},
+			"X-Method-Rate-Limit":       {"2000:10"},
+			"X-Method-Rate-Limit-Count": {"5:10"},
+		}
+		if strings.HasSuffix(req.URL.Path, "/timeline") {
+			header.Set("X-Method-Rate-Limit-Count", "9:10")
+		}
+		if strings.HasSuffix(req.URL.Path, "EUW1_404") {
+			return &http.Response{StatusCode: http.StatusNotFound, Header: header, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+	})
+}
+
+func TestCollector(t *testing.T) {
+	collector := New(Options{})
+	reg := prometheus.NewPedanticRegistry()
+	if err := collector.Register(reg); err != nil {
+		t.Fatal(err)
+	}
+
+	c := client.NewClient(client.Config{
+		APIKey:         "RGAPI-test",
+		RequestsPerMin: 6000,
+		Middleware:     []client.RequestMiddleware{fakeRiot},
+		Observers:      []client.Observer{collector},
+	}, nil)
+
+	ctx := context.Background()
+	if _, err := c.GetMatch(ctx, "EUW1_1", types.EUW1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetMatch(ctx, "EUW1_404", types.EUW1); !client.IsNotFound(err) {
+		t.Fatalf("expected 404, got %v", err)
+	}
+	if _, err := c.GetMatchTimeline(ctx, "EUW1_1", types.EUW1); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `
+# HELP lol_client_errors_total Riot API calls that failed, by HTTP status.
+# TYPE lol_client_errors_total counter
+lol_client_errors_total{endpoint="match-v5.getMatch",routing_value="europe",status="404"} 1
+# HELP lol_client_rate_limit_headroom Requests left in a Riot rate-limit window as of the last response.
+# TYPE lol_client_rate_limit_headroom gauge
+lol_client_rate_limit_headroom{api_key="default",endpoint="",routing_value="europe",scope="app",window_seconds="1"} 19
+lol_client_rate_limit_headroom{api_key="default",endpoint="",routing_value="europe",scope="app",window_seconds="120"} 93
+lol_client_rate_limit_headroom{api_key="default",endpoint="match-v5.getMatch",routing_value="europe",scope="method",window_seconds="10"} 1995
+lol_client_rate_limit_headroom{api_key="default",endpoint="match-v5.getTimeline",routing_value="europe",scope="method",window_seconds="10"} 1991
+# HELP lol_client_requests_total Riot API calls made.
+# TYPE lol_client_requests_total counter
+lol_client_requests_total{endpoint="match-v5.getMatch",routing_value="europe"} 2
+lol_client_requests_total{endpoint="match-v5.getTimeline",routing_value="europe"} 1
+# HELP lol_client_limiter_queue_depth Calls currently waiting on the rate limiter.
+# TYPE lol_client_limiter_queue_depth gauge
+lol_client_limiter_queue_depth{routing_value="europe"} 0
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"lol_client_errors_total", "lol_client_rate_limit_headroom", "lol_client_requests_total", "lol_client_limiter_queue_depth"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(collector, "lol_client_request_duration_seconds"); n != 2 {
+		t.Errorf("expected two duration series, got %d", n)
+	}
+}
@@ -0,0 +1,119 @@
+// Package redislimit provides a client.RateLimiter backed by Redis, so that
+// several processes sharing one API key also share its rate limits.
+package redislimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Window allows Requests requests in any interval of length Per. Riot
+// enforces several windows at once, e.g. 20 per second and 100 per two
+// minutes for a development key.
+type Window struct {
+	Requests int
+	Per      time.Duration
+}
+
+// RateLimiter implements a sliding-window log per key and window in Redis
+// sorted sets. A request is admitted atomically in every window or in none.
+type RateLimiter struct {
+	client  redis.Scripter
+	prefix  string
+	windows []Window
+}
+
+// New returns a RateLimiter storing its state under keys starting with
+// prefix. All processes sharing a limit must use the same prefix and windows.
+//
+// The keys of one limit are named "{prefix:key}:requests:ms", one per window.
+// The braces are a Redis Cluster hash tag: they put every window of a limit
+// in the same slot, so the script can update them atomically on a cluster.
+func New(client redis.Scripter, prefix string, windows ...Window) *RateLimiter {
+	return &RateLimiter{
+		client:  client,
+		prefix:  prefix,
+		windows: windows,
+	}
+}
+
+// acquireScript admits a request if every window has room. It returns 0 on
+// success or the number of milliseconds until the fullest window frees up.
+// Redis' own clock is used so that processes with skewed clocks agree.
+var acquireScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local wait = 0
+for i, key in ipairs(KEYS) do
+	local limit = tonumber(ARGV[2 * i - 1])
+	local window = tonumber(ARGV[2 * i])
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+	if redis.call('ZCARD', key) >= limit then
+		local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+		local free = tonumber(oldest[2]) + window - now
+		if free > wait then
+			wait = free
+		end
+	end
+end
+if wait > 0 then
+	return wait
+end
+local member = ARGV[#ARGV]
+for i, key in ipairs(KEYS) do
+	redis.call('ZADD', key, now, member)
+	redis.call('PEXPIRE', key, tonumber(ARGV[2 * i]))
+end
+return 0
+`)
+
+// Wait blocks until a request on key is admitted in every window, or ctx is
+// done. Calls on the same key from any process share its limits.
+func (r *RateLimiter) Wait(ctx context.Context, key string) error {
+	if len(r.windows) == 0 {
+		return ctx.Err()
+	}
+
+	keys := make([]string, len(r.windows))
+	args := make([]any, 0, 2*len(r.windows)+1)
+	for i, w := range r.windows {
+		keys[i] = fmt.Sprintf("{%s:%s}:%d:%d", r.prefix, key, w.Requests, w.Per.Milliseconds())
+		args = append(args, w.Requests, w.Per.Milliseconds())
+	}
+
+	for {
+		member, err := newMember()
+		if err != nil {
+			return err
+		}
+
+		wait, err := acquireScript.Run(ctx, r.client, keys, append(args, member)...).Int64()
+		if err != nil {
+			return fmt.Errorf("rate limiter script failed: %w", err)
+		}
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(time.Duration(wait) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func newMember() (string, error) {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate request id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,89 @@
+package redislimit
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSharedLimitAcrossLimiters(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	// Two limiters stand in for two processes sharing one key.
+	window := Window{Requests: 3, Per: time.Hour}
+	limiters := []*RateLimiter{
+		New(rdb, "lol", window),
+		New(rdb, "lol", window),
+	}
+
+	var wg sync.WaitGroup
+	admitted := make(chan int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(l *RateLimiter) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			if l.Wait(ctx, "europe") == nil {
+				admitted <- 1
+			}
+		}(limiters[i%2])
+	}
+	wg.Wait()
+	close(admitted)
+
+	n := 0
+	for range admitted {
+		n++
+	}
+	if n != window.Requests {
+		t.Fatalf("admitted %d requests, want %d", n, window.Requests)
+	}
+
+	ctx := context.Background()
+	if err := limiters[0].Wait(ctx, "americas"); err != nil {
+		t.Fatalf("other routing values must not share the limit: %v", err)
+	}
+}
+
+func TestWaitsForWindowToSlide(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	limiter := New(rdb, "lol", Window{Requests: 1, Per: 50 * time.Millisecond})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx, "europe"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests with 1 per 50ms finished in %v", elapsed)
+	}
+}
+
+func TestWindowsOfAKeyShareAHashTag(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	limiter := New(rdb, "lol", Window{Requests: 20, Per: time.Second}, Window{Requests: 100, Per: 2 * time.Minute})
+	if err := limiter.Wait(context.Background(), "key:europe"); err != nil {
+		t.Fatal(err)
+	}
+	keys := server.Keys()
+	want := []string{"{lol:key:europe}:100:120000", "{lol:key:europe}:20:1000"}
+	if !slices.Equal(keys, want) {
+		t.Errorf("keys = %q, want %q", keys, want)
+	}
+}
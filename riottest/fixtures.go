@@ -0,0 +1,122 @@
+package riottest
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"path"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// The fixtures are ten players on EUW1 who played two ranked solo matches
+// against each other, Player1#EUW to Player10#EUW with PUUIDs
+// riottest-puuid-01 to riottest-puuid-10. Players 1-3 are challenger, 4-5
+// grandmaster, 6-7 master and 8-10 diamond I.
+const (
+	FixtureRegion = types.EUW1
+	FixtureQueue  = "RANKED_SOLO_5x5"
+	FixturePUUID  = "riottest-puuid-01"
+	// FixtureMatchID is the newer of the two fixture matches, played on
+	// patch 14.19; FixtureOlderMatchID was played on patch 14.18.
+	FixtureMatchID      = "EUW1_7000000001"
+	FixtureOlderMatchID = "EUW1_7000000002"
+)
+
+//go:embed fixtures
+var fixtures embed.FS
+
+func (s *Server) loadFixtures() error {
+	var accounts []json.RawMessage
+	if err := readFixture("fixtures/accounts.json", &accounts); err != nil {
+		return err
+	}
+	for _, body := range accounts {
+		var account types.Account
+		if err := json.Unmarshal(body, &account); err != nil {
+			return err
+		}
+		s.addAccount(account, body)
+	}
+
+	var summoners []json.RawMessage
+	if err := readFixture("fixtures/summoners.json", &summoners); err != nil {
+		return err
+	}
+	for _, body := range summoners {
+		var summoner types.Summoner
+		if err := json.Unmarshal(body, &summoner); err != nil {
+			return err
+		}
+		s.summoners[FixtureRegion.ToString()+"/"+summoner.PUUID.Value] = body
+	}
+
+	matches, err := fs.Glob(fixtures, "fixtures/matches/*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range matches {
+		body, err := fixtures.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := s.addMatch(body); err != nil {
+			return err
+		}
+	}
+
+	timelines, err := fs.Glob(fixtures, "fixtures/timelines/*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range timelines {
+		body, err := fixtures.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		s.timelines[path.Base(name[:len(name)-len(".json")])] = body
+	}
+
+	var leagues map[string]json.RawMessage
+	if err := readFixture("fixtures/leagues.json", &leagues); err != nil {
+		return err
+	}
+	for _, body := range leagues {
+		var league types.LeagueList
+		if err := json.Unmarshal(body, &league); err != nil {
+			return err
+		}
+		s.leagues[leagueKey(FixtureRegion.ToString(), league.Tier, league.Queue)] = body
+	}
+
+	var entries []types.LeagueEntry
+	if err := readFixture("fixtures/entries.json", &entries); err != nil {
+		return err
+	}
+	s.AddLeagueEntries(FixtureRegion, entries...)
+	return nil
+}
+
+func readFixture(name string, v any) error {
+	body, err := fixtures.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// FixtureMatch returns the decoded fixture match with the given ID, for
+// comparing against what a client returns.
+func FixtureMatch(id string) (types.Match, error) {
+	var match types.Match
+	err := readFixture("fixtures/matches/"+id+".json", &match)
+	return match, err
+}
+
+// FixtureTimeline returns the decoded timeline of the fixture match with the
+// given ID.
+func FixtureTimeline(id string) (types.MatchTimeline, error) {
+	var timeline types.MatchTimeline
+	err := readFixture("fixtures/timelines/"+id+".json", &timeline)
+	return timeline, err
+}
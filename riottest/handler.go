@@ -0,0 +1,355 @@
+package riottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+type routing int
+
+const (
+	platformRouting routing = iota
+	regionalRouting
+	accountRouting
+)
+
+// entriesPageSize is how many entries Riot returns per page of
+// league-v4.getLeagueEntries.
+const entriesPageSize = 205
+
+type historyEntry struct {
+	matchID  string
+	start    int64
+	queue    int
+	gameType string
+}
+
+func sortHistory(entries []historyEntry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].start > entries[j].start })
+}
+
+// route is one endpoint of the server.
+type route struct {
+	endpoint string
+	pattern  string
+	routing  routing
+	serve    func(s *Server, r *http.Request, host string) (int, []byte)
+}
+
+var routes = []route{
+	{"account-v1.getByPuuid", "GET /riot/account/v1/accounts/by-puuid/{puuid}", accountRouting, (*Server).serveAccountByPUUID},
+	{"account-v1.getByRiotId", "GET /riot/account/v1/accounts/by-riot-id/{gameName}/{tagLine}", accountRouting, (*Server).serveAccountByRiotID},
+	{"summoner-v4.getByPUUID", "GET /lol/summoner/v4/summoners/by-puuid/{puuid}", platformRouting, (*Server).serveSummoner},
+	{"match-v5.getMatchIdsByPUUID", "GET /lol/match/v5/matches/by-puuid/{puuid}/ids", regionalRouting, (*Server).serveMatchIDs},
+	{"match-v5.getMatch", "GET /lol/match/v5/matches/{matchId}", regionalRouting, (*Server).serveMatch},
+	{"match-v5.getTimeline", "GET /lol/match/v5/matches/{matchId}/timeline", regionalRouting, (*Server).serveTimeline},
+	{"league-v4.getChallengerLeague", "GET /lol/league/v4/challengerleagues/by-queue/{queue}", platformRouting, leagueServer("CHALLENGER")},
+	{"league-v4.getGrandmasterLeague", "GET /lol/league/v4/grandmasterleagues/by-queue/{queue}", platformRouting, leagueServer("GRANDMASTER")},
+	{"league-v4.getMasterLeague", "GET /lol/league/v4/masterleagues/by-queue/{queue}", platformRouting, leagueServer("MASTER")},
+	{"league-v4.getLeagueEntries", "GET /lol/league/v4/entries/{queue}/{tier}/{division}", platformRouting, (*Server).serveLeagueEntries},
+	{"league-v4.getLeagueEntriesByPUUID", "GET /lol/league/v4/entries/by-puuid/{puuid}", platformRouting, (*Server).serveLeagueEntriesByPUUID},
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, rt := range routes {
+		mux.HandleFunc(rt.pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(w, r, rt)
+		})
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, route{serve: func(*Server, *http.Request, string) (int, []byte) {
+			return errorBody(http.StatusNotFound, "Resource not found")
+		}})
+	})
+	return mux
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, rt route) {
+	host, _, _ := strings.Cut(r.Host, ".")
+	token := r.Header.Get("X-Riot-Token")
+
+	s.mu.Lock()
+	latency := s.latency
+	fault := s.matchFault(rt.endpoint)
+	s.mu.Unlock()
+	if fault != nil {
+		latency += fault.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	status, body := s.respond(w.Header(), r, rt, host, token, fault)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Endpoint: rt.endpoint,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		Query:    r.URL.Query(),
+		Status:   status,
+	})
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func (s *Server) respond(header http.Header, r *http.Request, rt route, host string, token string, fault *Fault) (int, []byte) {
+	s.mu.Lock()
+	if token == "" {
+		s.mu.Unlock()
+		return errorBody(http.StatusUnauthorized, "Unauthorized")
+	}
+	if len(s.keys) > 0 && !s.keys[token] {
+		s.mu.Unlock()
+		return errorBody(http.StatusForbidden, "Forbidden")
+	}
+	if rt.endpoint != "" {
+		if limited, retryAfter, limitType := s.count(header, token, host, rt.endpoint); limited {
+			s.mu.Unlock()
+			header.Set("Retry-After", strconv.Itoa(retryAfter))
+			header.Set("X-Rate-Limit-Type", limitType)
+			return errorBody(http.StatusTooManyRequests, "Rate limit exceeded")
+		}
+	}
+	s.mu.Unlock()
+
+	if fault != nil && fault.Status != 0 {
+		for name, values := range fault.Header {
+			header[name] = values
+		}
+		return errorBody(fault.Status, http.StatusText(fault.Status))
+	}
+	if rt.endpoint != "" && !validHost(rt.routing, host) {
+		return errorBody(http.StatusBadRequest, fmt.Sprintf("riottest: %s is not a valid routing value for %s", host, rt.endpoint))
+	}
+	return rt.serve(s, r, host)
+}
+
+// matchFault returns the first fault matching endpoint, using up one of its
+// Times.
+func (s *Server) matchFault(endpoint string) *Fault {
+	for i, f := range s.faults {
+		if f.Endpoint != "" && f.Endpoint != endpoint {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func validHost(kind routing, host string) bool {
+	switch kind {
+	case regionalRouting:
+		return host == "americas" || host == "asia" || host == "europe" || host == "sea"
+	case accountRouting:
+		return host == "americas" || host == "asia" || host == "europe"
+	}
+	var region types.Region
+	return region.UnmarshalText([]byte(host)) == nil
+}
+
+// cluster returns the match-v5 routing value serving the platform a match ID
+// starts with, e.g. europe for EUW1_123.
+func cluster(matchID string) string {
+	platform, _, _ := strings.Cut(matchID, "_")
+	var region types.Region
+	if region.UnmarshalText([]byte(platform)) != nil {
+		return ""
+	}
+	switch region {
+	case types.OC1:
+		return "sea"
+	case types.BR1, types.LA1, types.LA2, types.NA1:
+		return "americas"
+	case types.KR, types.JP1:
+		return "asia"
+	}
+	return "europe"
+}
+
+func errorBody(status int, message string) (int, []byte) {
+	body, _ := json.Marshal(map[string]any{
+		"status": map[string]any{"message": message, "status_code": status},
+	})
+	return status, body
+}
+
+func notFound(what string) (int, []byte) {
+	return errorBody(http.StatusNotFound, "Data not found - "+what)
+}
+
+func (s *Server) lookup(m map[string][]byte, key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, ok := m[key]
+	return body, ok
+}
+
+func (s *Server) serveAccountByPUUID(r *http.Request, host string) (int, []byte) {
+	if body, ok := s.lookup(s.accounts, r.PathValue("puuid")); ok {
+		return http.StatusOK, body
+	}
+	return notFound("No results found for player with riot id")
+}
+
+func (s *Server) serveAccountByRiotID(r *http.Request, host string) (int, []byte) {
+	s.mu.Lock()
+	puuid := s.riotIDs[riotIDKey(r.PathValue("gameName"), r.PathValue("tagLine"))]
+	s.mu.Unlock()
+	if body, ok := s.lookup(s.accounts, puuid); ok {
+		return http.StatusOK, body
+	}
+	return notFound("No results found for player with riot id " + r.PathValue("gameName") + "#" + r.PathValue("tagLine"))
+}
+
+func (s *Server) serveSummoner(r *http.Request, host string) (int, []byte) {
+	if body, ok := s.lookup(s.summoners, host+"/"+r.PathValue("puuid")); ok {
+		return http.StatusOK, body
+	}
+	return notFound("summoner not found")
+}
+
+func (s *Server) serveMatchIDs(r *http.Request, host string) (int, []byte) {
+	query := r.URL.Query()
+	start, count := 0, 20
+	if v, err := strconv.Atoi(query.Get("start")); err == nil {
+		start = v
+	}
+	if v, err := strconv.Atoi(query.Get("count")); err == nil {
+		count = v
+	}
+	if start < 0 || count < 0 || count > 100 {
+		return errorBody(http.StatusBadRequest, "Bad request - count must be between 0 and 100")
+	}
+	queue, _ := strconv.Atoi(query.Get("queue"))
+	startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+	endTime, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+
+	s.mu.Lock()
+	var ids []string
+	for _, entry := range s.history[r.PathValue("puuid")] {
+		switch {
+		case cluster(entry.matchID) != host,
+			queue != 0 && entry.queue != queue,
+			startTime != 0 && entry.start < startTime*1000,
+			endTime != 0 && entry.start > endTime*1000,
+			query.Get("type") != "" && !strings.EqualFold(query.Get("type"), gameTypeFilter(entry.gameType)):
+			continue
+		}
+		ids = append(ids, entry.matchID)
+	}
+	s.mu.Unlock()
+
+	ids = ids[min(start, len(ids)):]
+	ids = ids[:min(count, len(ids))]
+	if ids == nil {
+		ids = []string{}
+	}
+	body, _ := json.Marshal(ids)
+	return http.StatusOK, body
+}
+
+// gameTypeFilter maps a match's gameType to the values of the type filter
+// of match-v5.getMatchIdsByPUUID.
+func gameTypeFilter(gameType string) string {
+	switch gameType {
+	case "MATCHED_GAME":
+		return "ranked"
+	case "CUSTOM_GAME":
+		return "custom"
+	case "TUTORIAL_GAME":
+		return "tutorial"
+	}
+	return "normal"
+}
+
+func (s *Server) serveMatch(r *http.Request, host string) (int, []byte) {
+	id := r.PathValue("matchId")
+	if body, ok := s.lookup(s.matches, id); ok && cluster(id) == host {
+		return http.StatusOK, body
+	}
+	return notFound("match file not found")
+}
+
+func (s *Server) serveTimeline(r *http.Request, host string) (int, []byte) {
+	id := r.PathValue("matchId")
+	if body, ok := s.lookup(s.timelines, id); ok && cluster(id) == host {
+		return http.StatusOK, body
+	}
+	return notFound("match file not found")
+}
+
+func leagueServer(tier string) func(s *Server, r *http.Request, host string) (int, []byte) {
+	return func(s *Server, r *http.Request, host string) (int, []byte) {
+		if body, ok := s.lookup(s.leagues, leagueKey(host, tier, r.PathValue("queue"))); ok {
+			return http.StatusOK, body
+		}
+		return notFound("league not found")
+	}
+}
+
+func (s *Server) serveLeagueEntries(r *http.Request, host string) (int, []byte) {
+	page := 1
+	if v, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && v > 0 {
+		page = v
+	}
+
+	s.mu.Lock()
+	entries := s.entries[entriesKey(host, r.PathValue("queue"), r.PathValue("tier"), r.PathValue("division"))]
+	s.mu.Unlock()
+
+	from := min((page-1)*entriesPageSize, len(entries))
+	entries = entries[from:min(from+entriesPageSize, len(entries))]
+	if entries == nil {
+		entries = []types.LeagueEntry{}
+	}
+	body, _ := json.Marshal(entries)
+	return http.StatusOK, body
+}
+
+func (s *Server) serveLeagueEntriesByPUUID(r *http.Request, host string) (int, []byte) {
+	puuid := r.PathValue("puuid")
+	found := []types.LeagueEntry{}
+
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		if strings.HasPrefix(key, host+"/") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, entry := range s.entries[key] {
+			if entry.PUUID.Value == puuid {
+				found = append(found, entry)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	body, _ := json.Marshal(found)
+	return http.StatusOK, body
+}
@@ -0,0 +1,65 @@
+package riottest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// windowCounter counts requests in fixed windows, as Riot does: a window
+// starts with the first request after the previous one ended.
+type windowCounter struct {
+	counts []int
+	starts []time.Time
+}
+
+// count records a request against the app limit and the method limit of
+// endpoint, writes the rate-limit headers and reports whether the request
+// exceeds a limit. s.mu must be held.
+func (s *Server) count(header http.Header, token string, host string, endpoint string) (bool, int, string) {
+	now := time.Now()
+	appLimited, appRetry := s.countWindows(header, "X-App-Rate-Limit", "app/"+token+"/"+host, s.appLimits, now)
+	methodLimited, methodRetry := s.countWindows(header, "X-Method-Rate-Limit", "method/"+token+"/"+host+"/"+endpoint, s.methodLimits[endpoint], now)
+	switch {
+	case appLimited:
+		return true, appRetry, "application"
+	case methodLimited:
+		return true, methodRetry, "method"
+	}
+	return false, 0, ""
+}
+
+func (s *Server) countWindows(header http.Header, name string, key string, windows []Window, now time.Time) (bool, int) {
+	if len(windows) == 0 {
+		return false, 0
+	}
+	counter, ok := s.counters[key]
+	if !ok {
+		counter = &windowCounter{counts: make([]int, len(windows)), starts: make([]time.Time, len(windows))}
+		s.counters[key] = counter
+	}
+
+	limited := false
+	retryAfter := 0
+	limits := make([]string, len(windows))
+	counts := make([]string, len(windows))
+	for i, w := range windows {
+		if now.Sub(counter.starts[i]) >= w.Per {
+			counter.starts[i] = now
+			counter.counts[i] = 0
+		}
+		counter.counts[i]++
+		if counter.counts[i] > w.Requests {
+			limited = true
+			reset := counter.starts[i].Add(w.Per).Sub(now)
+			retryAfter = max(retryAfter, int((reset+time.Second-1)/time.Second))
+		}
+		seconds := int(w.Per / time.Second)
+		limits[i] = fmt.Sprintf("%d:%d", w.Requests, seconds)
+		counts[i] = fmt.Sprintf("%d:%d", min(counter.counts[i], w.Requests+1), seconds)
+	}
+	header.Set(name, strings.Join(limits, ","))
+	header.Set(name+"-Count", strings.Join(counts, ","))
+	return limited, retryAfter
+}
@@ -0,0 +1,310 @@
+// Package riottest provides a fake Riot API server for tests. It serves
+// account, summoner, match, timeline and league endpoints from fixture data,
+// emulates Riot's rate-limit headers and 429 responses, and can inject
+// latency and failures.
+//
+//	srv := riottest.NewServer()
+//	defer srv.Close()
+//	c := client.NewClient(client.Config{APIKey: "RGAPI-test", HTTPClient: srv.HTTPClient()}, nil)
+package riottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// Window is one rate-limit window, e.g. 100 requests per 2 minutes.
+type Window struct {
+	Requests int
+	Per      time.Duration
+}
+
+// DefaultAppRateLimit is the application rate limit of a new Server, that of
+// a Riot production key.
+var DefaultAppRateLimit = []Window{{Requests: 500, Per: 10 * time.Second}, {Requests: 30000, Per: 10 * time.Minute}}
+
+// Fault makes matching requests fail or slow down.
+type Fault struct {
+	// Endpoint restricts the fault to one endpoint, e.g. "match-v5.getMatch".
+	// Empty matches every request.
+	Endpoint string
+	// Status is the status code returned instead of the response. Zero only
+	// delays the request by Latency.
+	Status  int
+	Latency time.Duration
+	// Header is added to the failed response, e.g. Retry-After.
+	Header http.Header
+	// Times is how many requests the fault applies to; zero means all.
+	Times int
+}
+
+// Request is a request the server received.
+type Request struct {
+	Endpoint string
+	Host     string
+	Path     string
+	Query    url.Values
+	Status   int
+}
+
+// Server is a fake Riot API. All methods are safe for concurrent use.
+type Server struct {
+	// URL is the address of the underlying httptest.Server.
+	URL string
+
+	srv *httptest.Server
+
+	mu           sync.Mutex
+	keys         map[string]bool
+	accounts     map[string][]byte
+	riotIDs      map[string]string
+	summoners    map[string][]byte
+	matches      map[string][]byte
+	timelines    map[string][]byte
+	history      map[string][]historyEntry
+	leagues      map[string][]byte
+	entries      map[string][]types.LeagueEntry
+	appLimits    []Window
+	methodLimits map[string][]Window
+	counters     map[string]*windowCounter
+	latency      time.Duration
+	faults       []*Fault
+	requests     []Request
+}
+
+// NewServer starts a server serving the fixture data described in
+// fixtures.go.
+func NewServer() *Server {
+	s := NewEmptyServer()
+	if err := s.loadFixtures(); err != nil {
+		s.Close()
+		panic("riottest: load fixtures: " + err.Error())
+	}
+	return s
+}
+
+// NewEmptyServer starts a server without any data.
+func NewEmptyServer() *Server {
+	s := &Server{
+		accounts:     make(map[string][]byte),
+		riotIDs:      make(map[string]string),
+		summoners:    make(map[string][]byte),
+		matches:      make(map[string][]byte),
+		timelines:    make(map[string][]byte),
+		history:      make(map[string][]historyEntry),
+		leagues:      make(map[string][]byte),
+		entries:      make(map[string][]types.LeagueEntry),
+		appLimits:    DefaultAppRateLimit,
+		methodLimits: make(map[string][]Window),
+		counters:     make(map[string]*windowCounter),
+	}
+	s.srv = httptest.NewServer(s.handler())
+	s.URL = s.srv.URL
+	return s
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// HTTPClient returns a client sending requests for *.api.riotgames.com to
+// the server. Use it as client.Config.HTTPClient.
+func (s *Server) HTTPClient() *http.Client {
+	return &http.Client{Transport: s.Transport(), Timeout: 30 * time.Second}
+}
+
+// Transport returns a RoundTripper sending requests for *.api.riotgames.com
+// to the server. The original host is kept in the Host header, from which
+// the server reads the routing value.
+func (s *Server) Transport() http.RoundTripper {
+	target, _ := url.Parse(s.srv.URL)
+	return &rewriteTransport{target: target, next: s.srv.Client().Transport}
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.HasSuffix(req.URL.Hostname(), ".api.riotgames.com") {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Host = req.URL.Host
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
+
+// SetKeys restricts the server to the given API keys; others get 403.
+// Without keys any non-empty X-Riot-Token is accepted.
+func (s *Server) SetKeys(keys ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		s.keys[key] = true
+	}
+}
+
+// SetAppRateLimit replaces the application rate limit. Limits are counted
+// per API key and routing value like Riot's. No windows disables limiting
+// and the rate-limit headers.
+func (s *Server) SetAppRateLimit(windows ...Window) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appLimits = windows
+	s.counters = make(map[string]*windowCounter)
+}
+
+// SetMethodRateLimit sets the method rate limit of an endpoint, e.g.
+// "match-v5.getMatch".
+func (s *Server) SetMethodRateLimit(endpoint string, windows ...Window) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.methodLimits[endpoint] = windows
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Inject adds a fault. Faults are checked in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// AddAccount adds an account, served by every account-v1 routing value.
+func (s *Server) AddAccount(account types.Account) error {
+	body, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addAccount(account, body)
+	return nil
+}
+
+func (s *Server) addAccount(account types.Account, body []byte) {
+	s.accounts[account.PUUID.Value] = body
+	s.riotIDs[riotIDKey(account.GameName, account.TagLine)] = account.PUUID.Value
+}
+
+func riotIDKey(gameName string, tagLine string) string {
+	return strings.ToLower(gameName + "#" + tagLine)
+}
+
+// AddSummoner adds a summoner on the platform of region.
+func (s *Server) AddSummoner(region types.Region, summoner types.Summoner) error {
+	body, err := json.Marshal(summoner)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summoners[region.ToString()+"/"+summoner.PUUID.Value] = body
+	return nil
+}
+
+// AddMatch adds a match and lists it in the match history of each
+// participant. The match is served by the regional cluster of its platform.
+func (s *Server) AddMatch(match types.Match) error {
+	body, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+	return s.addMatch(body)
+}
+
+func (s *Server) addMatch(body []byte) error {
+	var match types.Match
+	if err := json.Unmarshal(body, &match); err != nil {
+		return err
+	}
+	id := match.Metadata.MatchID
+	if id == "" {
+		return fmt.Errorf("match without metadata.matchId")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matches[id] = body
+	for _, puuid := range match.Metadata.Participants {
+		entries := s.history[puuid.Value]
+		entries = append(entries, historyEntry{
+			matchID:  id,
+			start:    match.Info.GameStartTimestamp,
+			queue:    match.Info.QueueID,
+			gameType: match.Info.GameType,
+		})
+		sortHistory(entries)
+		s.history[puuid.Value] = entries
+	}
+	return nil
+}
+
+// AddTimeline adds the timeline of a match.
+func (s *Server) AddTimeline(timeline types.MatchTimeline) error {
+	body, err := json.Marshal(timeline)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timelines[timeline.Metadata.MatchID] = body
+	return nil
+}
+
+// SetLeague sets the challenger, grandmaster or master league of
+// league.Queue on the platform of region, chosen by league.Tier.
+func (s *Server) SetLeague(region types.Region, league types.LeagueList) error {
+	body, err := json.Marshal(league)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leagues[leagueKey(region.ToString(), league.Tier, league.Queue)] = body
+	return nil
+}
+
+func leagueKey(platform string, tier string, queue string) string {
+	return platform + "/" + strings.ToUpper(tier) + "/" + queue
+}
+
+// AddLeagueEntries adds ranked entries on the platform of region. Each
+// entry needs QueueType, Tier and Rank set.
+func (s *Server) AddLeagueEntries(region types.Region, entries ...types.LeagueEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range entries {
+		key := entriesKey(region.ToString(), entry.QueueType, entry.Tier, entry.Rank)
+		s.entries[key] = append(s.entries[key], entry)
+	}
+}
+
+func entriesKey(platform string, queue string, tier string, division string) string {
+	return platform + "/" + queue + "/" + strings.ToUpper(tier) + "/" + strings.ToUpper(division)
+}
@@ -0,0 +1,154 @@
+package riottest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/travior/lol-sdk/client"
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/types"
+)
+
+func newClient(srv *riottest.Server, config client.Config) *client.Client {
+	config.APIKey = "RGAPI-test"
+	config.HTTPClient = srv.HTTPClient()
+	config.DecodeMode = client.DecodeStrict
+	return client.NewClient(config, nil)
+}
+
+func TestServerServesFixtures(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	c := newClient(srv, client.Config{})
+	ctx := context.Background()
+	region := riottest.FixtureRegion
+
+	account, err := c.GetAccountByRiotID(ctx, "Player1", "EUW", region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.PUUID.Value != riottest.FixturePUUID {
+		t.Fatalf("unexpected account %+v", account)
+	}
+
+	summoner, err := c.GetSummonerByPUUID(ctx, account.PUUID, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summoner.PUUID.Value != riottest.FixturePUUID {
+		t.Errorf("unexpected summoner %+v", summoner)
+	}
+
+	ids, err := c.GetMatchHistoryByPUUID(ctx, account.PUUID, region, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != riottest.FixtureMatchID || ids[1] != riottest.FixtureOlderMatchID {
+		t.Fatalf("unexpected match history %v", ids)
+	}
+
+	match, err := c.GetMatch(ctx, ids[0], region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(match.Info.Participants) != 10 || match.Info.Participants[0].Challenges.KDA == 0 {
+		t.Errorf("unexpected match %+v", match.Info)
+	}
+	timeline, err := c.GetMatchTimeline(ctx, ids[0], region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeline.Info.Frames) == 0 {
+		t.Error("expected timeline frames")
+	}
+
+	challengers, err := c.GetChallengerLeague(ctx, riottest.FixtureQueue, region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if challengers.Tier != "CHALLENGER" || len(challengers.Entries) == 0 {
+		t.Errorf("unexpected league %+v", challengers)
+	}
+	entries, err := c.GetLeagueEntries(ctx, riottest.FixtureQueue, "DIAMOND", "I", region)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("expected 3 diamond entries, got %d", len(entries))
+	}
+
+	if _, err := c.GetMatch(ctx, "EUW1_1", region); !client.IsNotFound(err) {
+		t.Errorf("expected 404 for unknown match, got %v", err)
+	}
+}
+
+func TestServerRejectsWrongRouting(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, "https://europe.api.riotgames.com/lol/summoner/v4/summoners/by-puuid/"+riottest.FixturePUUID, nil)
+	req.Header.Set("X-Riot-Token", "RGAPI-test")
+	resp, err := srv.HTTPClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected 400 for summoner on a regional host, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerEmulatesRateLimits(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	srv.SetAppRateLimit(riottest.Window{Requests: 2, Per: time.Minute})
+	c := newClient(srv, client.Config{DisableDeduplication: true})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.GetMatchRaw(ctx, riottest.FixtureMatchID, riottest.FixtureRegion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Header.Get("X-App-Rate-Limit"); got != "2:60" {
+			t.Errorf("X-App-Rate-Limit = %q", got)
+		}
+	}
+
+	_, err := c.GetMatch(ctx, riottest.FixtureMatchID, riottest.FixtureRegion)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %v", err)
+	}
+	if apiErr.Header.Get("Retry-After") == "" || apiErr.Header.Get("X-Rate-Limit-Type") != "application" {
+		t.Errorf("unexpected 429 headers %v", apiErr.Header)
+	}
+}
+
+func TestServerInjectsFaults(t *testing.T) {
+	srv := riottest.NewServer()
+	defer srv.Close()
+	srv.Inject(riottest.Fault{Endpoint: client.EndpointMatch, Status: http.StatusServiceUnavailable, Times: 2})
+	c := newClient(srv, client.Config{MaxRetries: 2, RetryBackoff: time.Millisecond})
+
+	if _, err := c.GetMatch(context.Background(), riottest.FixtureMatchID, riottest.FixtureRegion); err != nil {
+		t.Fatalf("expected retries to succeed, got %v", err)
+	}
+	var statuses []int
+	for _, req := range srv.Requests() {
+		statuses = append(statuses, req.Status)
+	}
+	if len(statuses) != 3 || statuses[0] != 503 || statuses[1] != 503 || statuses[2] != 200 {
+		t.Errorf("unexpected statuses %v", statuses)
+	}
+
+	srv.Inject(riottest.Fault{Latency: 50 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetSummonerByPUUID(ctx, types.NewPUUID(riottest.FixturePUUID), riottest.FixtureRegion); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
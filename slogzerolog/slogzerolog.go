@@ -0,0 +1,141 @@
+// Package slogzerolog adapts a zerolog.Logger to log/slog, so that code
+// logging through *slog.Logger, such as the client package, can write to an
+// existing zerolog setup.
+package slogzerolog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// Handler is a slog.Handler writing records to a zerolog.Logger. Attributes
+// inside groups are written as nested objects; attributes added with
+// WithAttrs and those of the record itself are nested separately, so a group
+// can appear twice in one line.
+type Handler struct {
+	logger zerolog.Logger
+	attrs  []slog.Attr
+	groups []string
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+func NewHandler(logger zerolog.Logger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// New returns a *slog.Logger writing to logger.
+func New(logger zerolog.Logger) *slog.Logger {
+	return slog.New(NewHandler(logger))
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.GetLevel() <= zerologLevel(level) && zerolog.GlobalLevel() <= zerologLevel(level)
+}
+
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	event := h.logger.WithLevel(zerologLevel(record.Level))
+	if event == nil {
+		return nil
+	}
+
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+
+	// Attributes added with WithAttrs belong to the groups open at the
+	// time, so they are nested the same way as the record's own ones.
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{slog.Attr{Key: h.groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	for _, attr := range append(h.attrs, attrs...) {
+		addAttr(event, attr)
+	}
+
+	// Timestamps are left to the zerolog.Logger's own configuration.
+	event.Msg(record.Message)
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = []slog.Attr{slog.Attr{Key: h.groups[i], Value: slog.GroupValue(attrs...)}}
+	}
+	clone := *h
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(append([]string(nil), h.groups...), name)
+	return &clone
+}
+
+func zerologLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case level >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	}
+	return zerolog.DebugLevel
+}
+
+func addAttr(event *zerolog.Event, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	switch value.Kind() {
+	case slog.KindString:
+		event.Str(attr.Key, value.String())
+	case slog.KindInt64:
+		event.Int64(attr.Key, value.Int64())
+	case slog.KindUint64:
+		event.Uint64(attr.Key, value.Uint64())
+	case slog.KindFloat64:
+		event.Float64(attr.Key, value.Float64())
+	case slog.KindBool:
+		event.Bool(attr.Key, value.Bool())
+	case slog.KindDuration:
+		event.Dur(attr.Key, value.Duration())
+	case slog.KindTime:
+		event.Time(attr.Key, value.Time())
+	case slog.KindGroup:
+		group := value.Group()
+		if len(group) == 0 {
+			return
+		}
+		if attr.Key == "" {
+			for _, member := range group {
+				addAttr(event, member)
+			}
+			return
+		}
+		dict := zerolog.Dict()
+		for _, member := range group {
+			addAttr(dict, member)
+		}
+		event.Dict(attr.Key, dict)
+	default:
+		if err, ok := value.Any().(error); ok {
+			event.AnErr(attr.Key, err)
+			return
+		}
+		event.Interface(attr.Key, value.Any())
+	}
+}
@@ -0,0 +1,44 @@
+package slogzerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestHandlerWritesZerologFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	logger.Debug("dropped")
+	logger.With("client", "lol").WithGroup("call").Warn("API call failed",
+		"endpoint", "match-v5.getMatch",
+		"status", 429,
+		"duration", 1500*time.Millisecond,
+		"error", errors.New("throttled"),
+	)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(lines), buf.String())
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(lines[0], &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["level"] != "warn" || got["message"] != "API call failed" || got["client"] != "lol" {
+		t.Errorf("unexpected line %v", got)
+	}
+	call, ok := got["call"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected group call, got %v", got)
+	}
+	if call["endpoint"] != "match-v5.getMatch" || call["status"] != float64(429) || call["error"] != "throttled" {
+		t.Errorf("unexpected group %v", call)
+	}
+}
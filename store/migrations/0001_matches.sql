@@ -0,0 +1,103 @@
+-- Matches, normalised for querying. raw keeps the complete match as JSON so
+-- that Get returns every field, including those without a column.
+CREATE TABLE matches (
+    match_id      TEXT PRIMARY KEY,
+    platform_id   TEXT NOT NULL,
+    game_id       INTEGER NOT NULL,
+    queue_id      INTEGER NOT NULL,
+    map_id        INTEGER NOT NULL,
+    game_mode     TEXT NOT NULL,
+    game_type     TEXT NOT NULL,
+    game_version  TEXT NOT NULL,
+    patch_major   INTEGER NOT NULL,
+    patch_minor   INTEGER NOT NULL,
+    game_creation INTEGER NOT NULL,
+    game_start    INTEGER NOT NULL,
+    game_end      INTEGER NOT NULL,
+    game_duration INTEGER NOT NULL,
+    data_version  TEXT NOT NULL,
+    raw           TEXT NOT NULL
+);
+CREATE INDEX matches_queue ON matches (queue_id, game_start);
+CREATE INDEX matches_patch ON matches (patch_major, patch_minor, game_start);
+CREATE INDEX matches_start ON matches (game_start);
+
+CREATE TABLE participants (
+    match_id                        TEXT NOT NULL REFERENCES matches (match_id) ON DELETE CASCADE,
+    participant_id                  INTEGER NOT NULL,
+    puuid                           TEXT NOT NULL,
+    riot_id_game_name               TEXT NOT NULL,
+    riot_id_tagline                 TEXT NOT NULL,
+    team_id                         INTEGER NOT NULL,
+    team_position                   TEXT NOT NULL,
+    champion_id                     INTEGER NOT NULL,
+    champion_name                   TEXT NOT NULL,
+    champ_level                     INTEGER NOT NULL,
+    kills                           INTEGER NOT NULL,
+    deaths                          INTEGER NOT NULL,
+    assists                         INTEGER NOT NULL,
+    gold_earned                     INTEGER NOT NULL,
+    total_minions_killed            INTEGER NOT NULL,
+    neutral_minions_killed          INTEGER NOT NULL,
+    total_damage_dealt_to_champions INTEGER NOT NULL,
+    total_damage_taken              INTEGER NOT NULL,
+    vision_score                    INTEGER NOT NULL,
+    item0                           INTEGER NOT NULL,
+    item1                           INTEGER NOT NULL,
+    item2                           INTEGER NOT NULL,
+    item3                           INTEGER NOT NULL,
+    item4                           INTEGER NOT NULL,
+    item5                           INTEGER NOT NULL,
+    item6                           INTEGER NOT NULL,
+    summoner1_id                    INTEGER NOT NULL,
+    summoner2_id                    INTEGER NOT NULL,
+    win                             INTEGER NOT NULL,
+    PRIMARY KEY (match_id, participant_id)
+);
+CREATE INDEX participants_puuid ON participants (puuid);
+CREATE INDEX participants_champion ON participants (champion_id);
+
+CREATE TABLE teams (
+    match_id          TEXT NOT NULL REFERENCES matches (match_id) ON DELETE CASCADE,
+    team_id           INTEGER NOT NULL,
+    win               INTEGER NOT NULL,
+    baron_kills       INTEGER NOT NULL,
+    champion_kills    INTEGER NOT NULL,
+    dragon_kills      INTEGER NOT NULL,
+    inhibitor_kills   INTEGER NOT NULL,
+    rift_herald_kills INTEGER NOT NULL,
+    tower_kills       INTEGER NOT NULL,
+    first_baron       INTEGER NOT NULL,
+    first_blood       INTEGER NOT NULL,
+    first_dragon      INTEGER NOT NULL,
+    first_inhibitor   INTEGER NOT NULL,
+    first_rift_herald INTEGER NOT NULL,
+    first_tower       INTEGER NOT NULL,
+    PRIMARY KEY (match_id, team_id)
+);
+
+CREATE TABLE bans (
+    match_id    TEXT NOT NULL REFERENCES matches (match_id) ON DELETE CASCADE,
+    team_id     INTEGER NOT NULL,
+    pick_turn   INTEGER NOT NULL,
+    champion_id INTEGER NOT NULL,
+    PRIMARY KEY (match_id, team_id, pick_turn)
+);
+
+-- One row per rune: style is the description of the tree ("primaryStyle"
+-- or "subStyle") and slot the position of the rune within it. Stat shards
+-- are stored with style "statPerks" and slots 0 (offense), 1 (flex) and
+-- 2 (defense).
+CREATE TABLE perks (
+    match_id       TEXT NOT NULL REFERENCES matches (match_id) ON DELETE CASCADE,
+    participant_id INTEGER NOT NULL,
+    style          TEXT NOT NULL,
+    style_id       INTEGER NOT NULL,
+    slot           INTEGER NOT NULL,
+    perk_id        INTEGER NOT NULL,
+    var1           INTEGER NOT NULL,
+    var2           INTEGER NOT NULL,
+    var3           INTEGER NOT NULL,
+    PRIMARY KEY (match_id, participant_id, style, slot)
+);
+CREATE INDEX perks_perk ON perks (perk_id);
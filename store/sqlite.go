@@ -0,0 +1,277 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// iteratePage is the number of matches Iterate reads per query. Reading in
+// pages keeps no connection busy while the caller handles a match, so the
+// caller may write to the store during iteration.
+const iteratePage = 100
+
+// SQLiteStore is a MatchStore backed by a SQLite database. Besides the
+// complete match as JSON it stores each match in the matches,
+// participants, teams, bans and perks tables; see migrations/ for the
+// schema.
+type SQLiteStore struct {
+	db *sql.DB
+	// writeMu serializes writes. SQLite allows one writer at a time, and
+	// writers queued only on busy_timeout fail under sustained load.
+	writeMu sync.Mutex
+}
+
+var _ MatchStore = (*SQLiteStore)(nil)
+
+// OpenSQLite opens or creates the database file at path and migrates it to
+// the current schema.
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	// Writes are serialized by writeMu; busy_timeout and immediate
+	// transactions cover other processes writing to the same file.
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)", "foreign_keys(1)"},
+		"_txlock": {"immediate"},
+	}.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(context.Background(), db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate %s: %w", path, err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// DB returns the underlying database, for queries the store has no method
+// for.
+func (s *SQLiteStore) DB() *sql.DB {
+	return s.db
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteStore) Put(ctx context.Context, match *types.Match) error {
+	raw, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	id := match.Metadata.MatchID
+	// Replacing the match row deletes its child rows through ON DELETE
+	// CASCADE.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM matches WHERE match_id = ?`, id); err != nil {
+		return err
+	}
+	info := match.Info
+	patch := info.Patch()
+	if _, err := tx.ExecContext(ctx, `INSERT INTO matches (
+		match_id, platform_id, game_id, queue_id, map_id, game_mode, game_type, game_version,
+		patch_major, patch_minor, game_creation, game_start, game_end, game_duration, data_version, raw
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, info.PlatformID, info.GameID, info.QueueID, info.MapID, info.GameMode, info.GameType, info.GameVersion,
+		patch.Major, patch.Minor, info.GameCreation, info.GameStartTimestamp, info.GameEndTimestamp, info.GameDuration,
+		match.Metadata.DataVersion, raw,
+	); err != nil {
+		return err
+	}
+
+	for _, p := range info.Participants {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO participants (
+			match_id, participant_id, puuid, riot_id_game_name, riot_id_tagline, team_id, team_position,
+			champion_id, champion_name, champ_level, kills, deaths, assists, gold_earned,
+			total_minions_killed, neutral_minions_killed, total_damage_dealt_to_champions, total_damage_taken,
+			vision_score, item0, item1, item2, item3, item4, item5, item6, summoner1_id, summoner2_id, win
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, p.ParticipantID, p.PUUID.Value, p.RiotIDGameName, p.RiotIDTagline, p.TeamID, p.TeamPosition,
+			p.ChampionID, p.ChampionName, p.ChampLevel, p.Kills, p.Deaths, p.Assists, p.GoldEarned,
+			p.TotalMinionsKilled, p.NeutralMinionsKilled, p.TotalDamageDealtToChampions, p.TotalDamageTaken,
+			p.VisionScore, p.Item0, p.Item1, p.Item2, p.Item3, p.Item4, p.Item5, p.Item6, p.Summoner1ID, p.Summoner2ID, p.Win,
+		); err != nil {
+			return err
+		}
+		if err := insertPerks(ctx, tx, id, p); err != nil {
+			return err
+		}
+	}
+
+	for _, team := range info.Teams {
+		o := team.Objectives
+		if _, err := tx.ExecContext(ctx, `INSERT INTO teams (
+			match_id, team_id, win, baron_kills, champion_kills, dragon_kills, inhibitor_kills, rift_herald_kills, tower_kills,
+			first_baron, first_blood, first_dragon, first_inhibitor, first_rift_herald, first_tower
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, team.TeamID, team.Win, o.Baron.Kills, o.Champion.Kills, o.Dragon.Kills, o.Inhibitor.Kills, o.RiftHerald.Kills, o.Tower.Kills,
+			o.Baron.First, o.Champion.First, o.Dragon.First, o.Inhibitor.First, o.RiftHerald.First, o.Tower.First,
+		); err != nil {
+			return err
+		}
+		for _, ban := range team.Bans {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO bans (match_id, team_id, pick_turn, champion_id) VALUES (?, ?, ?, ?)`,
+				id, team.TeamID, ban.PickTurn, ban.ChampionID); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func insertPerks(ctx context.Context, tx *sql.Tx, matchID string, p types.Participant) error {
+	const insert = `INSERT INTO perks (match_id, participant_id, style, style_id, slot, perk_id, var1, var2, var3)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, style := range p.Perks.Styles {
+		for slot, selection := range style.Selections {
+			if _, err := tx.ExecContext(ctx, insert, matchID, p.ParticipantID, style.Description, style.Style,
+				slot, selection.Perk, selection.Var1, selection.Var2, selection.Var3); err != nil {
+				return err
+			}
+		}
+	}
+	stats := p.Perks.StatPerks
+	for slot, perk := range []int{stats.Offense, stats.Flex, stats.Defense} {
+		if _, err := tx.ExecContext(ctx, insert, matchID, p.ParticipantID, "statPerks", 0, slot, perk, 0, 0, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, matchID string) (*types.Match, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx, `SELECT raw FROM matches WHERE match_id = ?`, matchID).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeMatch(raw)
+}
+
+func (s *SQLiteStore) Has(ctx context.Context, matchID string) (bool, error) {
+	var found int
+	err := s.db.QueryRowContext(ctx, `SELECT 1 FROM matches WHERE match_id = ?`, matchID).Scan(&found)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func decodeMatch(raw []byte) (*types.Match, error) {
+	var match types.Match
+	if err := json.Unmarshal(raw, &match); err != nil {
+		return nil, fmt.Errorf("decode stored match: %w", err)
+	}
+	return &match, nil
+}
+
+func (s *SQLiteStore) Iterate(ctx context.Context, q Query) iter.Seq2[*types.Match, error] {
+	return func(yield func(*types.Match, error) bool) {
+		where, args := q.where()
+		yielded := 0
+		// Pages continue after the last match of the previous page in
+		// (game_start, match_id) order.
+		var lastStart int64
+		var lastID string
+		for page := 0; ; page++ {
+			conditions, pageArgs := where, args
+			if page > 0 {
+				conditions = append(slices.Clone(conditions), "(m.game_start, m.match_id) < (?, ?)")
+				pageArgs = append(slices.Clone(pageArgs), lastStart, lastID)
+			}
+			limit := iteratePage
+			if q.Limit > 0 {
+				limit = min(limit, q.Limit-yielded)
+			}
+			query := `SELECT m.game_start, m.match_id, m.raw FROM matches m`
+			if len(conditions) > 0 {
+				query += " WHERE " + strings.Join(conditions, " AND ")
+			}
+			query += fmt.Sprintf(" ORDER BY m.game_start DESC, m.match_id DESC LIMIT %d", limit)
+
+			rows, err := s.db.QueryContext(ctx, query, pageArgs...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			var raws [][]byte
+			for rows.Next() {
+				var raw []byte
+				if err := rows.Scan(&lastStart, &lastID, &raw); err != nil {
+					rows.Close()
+					yield(nil, err)
+					return
+				}
+				raws = append(raws, raw)
+			}
+			err = rows.Err()
+			rows.Close()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, raw := range raws {
+				match, err := decodeMatch(raw)
+				if !yield(match, err) || err != nil {
+					return
+				}
+				yielded++
+			}
+			if len(raws) < limit || (q.Limit > 0 && yielded >= q.Limit) {
+				return
+			}
+		}
+	}
+}
+
+func (q Query) where() ([]string, []any) {
+	var conditions []string
+	var args []any
+	if !q.PUUID.IsZero() {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM participants p WHERE p.match_id = m.match_id AND p.puuid = ?)")
+		args = append(args, q.PUUID.Value)
+	}
+	if q.QueueID != 0 {
+		conditions = append(conditions, "m.queue_id = ?")
+		args = append(args, q.QueueID)
+	}
+	if !q.MinPatch.IsZero() {
+		conditions = append(conditions, "(m.patch_major, m.patch_minor) >= (?, ?)")
+		args = append(args, q.MinPatch.Major, q.MinPatch.Minor)
+	}
+	if !q.MaxPatch.IsZero() {
+		conditions = append(conditions, "(m.patch_major, m.patch_minor) <= (?, ?)")
+		args = append(args, q.MaxPatch.Major, q.MaxPatch.Minor)
+	}
+	if !q.From.IsZero() {
+		conditions = append(conditions, "m.game_start >= ?")
+		args = append(args, q.From.UnixMilli())
+	}
+	if !q.To.IsZero() {
+		conditions = append(conditions, "m.game_start < ?")
+		args = append(args, q.To.UnixMilli())
+	}
+	return conditions, args
+}
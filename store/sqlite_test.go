@@ -0,0 +1,201 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/store"
+	"github.com/travior/lol-sdk/types"
+)
+
+func openStore(t *testing.T, path string) *store.SQLiteStore {
+	t.Helper()
+	st, err := store.OpenSQLite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+func fixtureMatches(t *testing.T) (types.Match, types.Match) {
+	t.Helper()
+	newer, err := riottest.FixtureMatch(riottest.FixtureMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	older, err := riottest.FixtureMatch(riottest.FixtureOlderMatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newer, older
+}
+
+func collect(t *testing.T, st store.MatchStore, q store.Query) []string {
+	t.Helper()
+	var ids []string
+	for match, err := range st.Iterate(context.Background(), q) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, match.Metadata.MatchID)
+	}
+	return ids
+}
+
+func TestSQLitePutGet(t *testing.T) {
+	st := openStore(t, filepath.Join(t.TempDir(), "matches.db"))
+	ctx := context.Background()
+	newer, _ := fixtureMatches(t)
+
+	if ok, err := st.Has(ctx, newer.Metadata.MatchID); err != nil || ok {
+		t.Fatalf("Has before Put = %v, %v", ok, err)
+	}
+	if _, err := st.Get(ctx, newer.Metadata.MatchID); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := st.Put(ctx, &newer); err != nil {
+		t.Fatal(err)
+	}
+	// Putting a match again replaces it rather than duplicating rows.
+	if err := st.Put(ctx, &newer); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := st.Has(ctx, newer.Metadata.MatchID); err != nil || !ok {
+		t.Fatalf("Has after Put = %v, %v", ok, err)
+	}
+	got, err := st.Get(ctx, newer.Metadata.MatchID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*got, newer) {
+		t.Error("stored match differs from the original")
+	}
+
+	counts := map[string]int{"matches": 1, "participants": 10, "teams": 2}
+	bans := 0
+	for _, team := range newer.Info.Teams {
+		bans += len(team.Bans)
+	}
+	counts["bans"] = bans
+	perks := 0
+	for _, p := range newer.Info.Participants {
+		for _, style := range p.Perks.Styles {
+			perks += len(style.Selections)
+		}
+		perks += 3
+	}
+	counts["perks"] = perks
+	for table, want := range counts {
+		var n int
+		if err := st.DB().QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", table)).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Errorf("%s has %d rows, want %d", table, n, want)
+		}
+	}
+
+	var kills int
+	err = st.DB().QueryRow(`SELECT kills FROM participants WHERE match_id = ? AND puuid = ?`,
+		newer.Metadata.MatchID, riottest.FixturePUUID).Scan(&kills)
+	if err != nil || kills != newer.Info.Participants[0].Kills {
+		t.Errorf("participant row has kills %d, %v", kills, err)
+	}
+}
+
+func TestSQLiteIterate(t *testing.T) {
+	st := openStore(t, filepath.Join(t.TempDir(), "matches.db"))
+	ctx := context.Background()
+	newer, older := fixtureMatches(t)
+	flex := newer
+	flex.Metadata.MatchID = "EUW1_7000000010"
+	flex.Info.QueueID = 440
+	flex.Info.GameStartTimestamp = newer.Info.GameStartTimestamp + 1
+	flex.Info.Participants = flex.Info.Participants[1:]
+	for _, m := range []*types.Match{&older, &newer, &flex} {
+		if err := st.Put(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newerStart := time.UnixMilli(newer.Info.GameStartTimestamp)
+	cases := []struct {
+		name  string
+		query store.Query
+		want  []string
+	}{
+		{"all newest first", store.Query{}, []string{flex.Metadata.MatchID, newer.Metadata.MatchID, older.Metadata.MatchID}},
+		{"puuid", store.Query{PUUID: types.NewPUUID(riottest.FixturePUUID)}, []string{newer.Metadata.MatchID, older.Metadata.MatchID}},
+		{"queue", store.Query{QueueID: 440}, []string{flex.Metadata.MatchID}},
+		{"min patch", store.Query{MinPatch: types.MustParseGameVersion("14.19")}, []string{flex.Metadata.MatchID, newer.Metadata.MatchID}},
+		{"max patch", store.Query{MaxPatch: types.MustParseGameVersion("14.18")}, []string{older.Metadata.MatchID}},
+		{"time", store.Query{From: newerStart, To: newerStart.Add(time.Millisecond)}, []string{newer.Metadata.MatchID}},
+		{"limit", store.Query{Limit: 2}, []string{flex.Metadata.MatchID, newer.Metadata.MatchID}},
+	}
+	for _, c := range cases {
+		if got := collect(t, st, c.query); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSQLiteIteratePagesAndConcurrentPuts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "matches.db")
+	st := openStore(t, path)
+	ctx := context.Background()
+	base, _ := fixtureMatches(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 250)
+	for i := range 250 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m := base
+			m.Metadata.MatchID = fmt.Sprintf("EUW1_%d", 1000+i)
+			m.Info.GameStartTimestamp = int64(i)
+			errs <- st.Put(ctx, &m)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Iterating across pages while writing to the store must not block.
+	ids := collect(t, st, store.Query{})
+	if len(ids) != 250 || ids[0] != "EUW1_1249" || ids[249] != "EUW1_1000" {
+		t.Fatalf("unexpected iteration of %d matches", len(ids))
+	}
+	for match, err := range st.Iterate(ctx, store.Query{Limit: 150}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := st.Put(ctx, match); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reopening an existing database applies no migration twice.
+	st.Close()
+	st = openStore(t, path)
+	var migrations int
+	if err := st.DB().QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&migrations); err != nil || migrations != 1 {
+		t.Errorf("schema_migrations has %d rows, %v", migrations, err)
+	}
+	if got := len(collect(t, st, store.Query{})); got != 250 {
+		t.Errorf("reopened store has %d matches", got)
+	}
+}
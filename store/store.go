@@ -0,0 +1,59 @@
+// Package store persists matches. MatchStore is the interface code should
+// depend on; SQLiteStore implements it on a single SQLite file, normalising
+// each match into tables that can also be queried with SQL directly.
+//
+//	st, err := store.OpenSQLite("matches.db")
+//	...
+//	err = st.Put(ctx, match)
+//	for match, err := range st.Iterate(ctx, store.Query{PUUID: puuid, QueueID: 420}) {
+//		...
+//	}
+//
+// A MatchStore has the Put method of crawler.Sink, so a crawl can write
+// straight into it.
+//
+// TimelineWriter is separate from MatchStore: it flattens match timelines into
+// frame and event rows and writes them as Parquet files for analysis tools.
+package store
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// ErrNotFound is returned by Get for matches that are not stored.
+var ErrNotFound = errors.New("store: match not found")
+
+// MatchStore stores matches by match ID. Implementations are safe for
+// concurrent use.
+type MatchStore interface {
+	// Put stores match, replacing a stored match with the same ID.
+	Put(ctx context.Context, match *types.Match) error
+	Get(ctx context.Context, matchID string) (*types.Match, error)
+	Has(ctx context.Context, matchID string) (bool, error)
+	// Iterate returns the stored matches selected by q, newest first.
+	// Iteration ends with the first error.
+	Iterate(ctx context.Context, q Query) iter.Seq2[*types.Match, error]
+	Close() error
+}
+
+// Query selects matches. Zero fields select everything.
+type Query struct {
+	// PUUID selects the matches the player took part in.
+	PUUID   types.PUUID
+	QueueID int
+	// MinPatch and MaxPatch select matches played on patches within the
+	// range, inclusive.
+	MinPatch types.GameVersion
+	MaxPatch types.GameVersion
+	// From and To select matches by start time, From inclusive and To
+	// exclusive.
+	From time.Time
+	To   time.Time
+	// Limit stops the iteration after that many matches.
+	Limit int
+}
@@ -0,0 +1,315 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/travior/lol-sdk/types"
+)
+
+// FrameRow is one participant in one timeline frame, the flattened form of
+// a TimelineParticipantFrame. Frames are taken every FrameInterval
+// (usually 60 seconds); values are totals at the time of the frame.
+type FrameRow struct {
+	MatchID string `parquet:"match_id,dict"`
+	GameID  int64  `parquet:"game_id"`
+	// FrameIndex counts frames from 0; TimestampMs is game time.
+	FrameIndex    int32  `parquet:"frame_index"`
+	TimestampMs   int64  `parquet:"timestamp_ms"`
+	ParticipantID int32  `parquet:"participant_id"`
+	PUUID         string `parquet:"puuid,dict"`
+	// TeamID is 100 for participants 1-5 and 200 for 6-10.
+	TeamID int32 `parquet:"team_id"`
+
+	Level                    int32 `parquet:"level"`
+	XP                       int32 `parquet:"xp"`
+	CurrentGold              int32 `parquet:"current_gold"`
+	TotalGold                int32 `parquet:"total_gold"`
+	GoldPerSecond            int32 `parquet:"gold_per_second"`
+	MinionsKilled            int32 `parquet:"minions_killed"`
+	JungleMinionsKilled      int32 `parquet:"jungle_minions_killed"`
+	TimeEnemySpentControlled int32 `parquet:"time_enemy_spent_controlled"`
+	PositionX                int32 `parquet:"position_x"`
+	PositionY                int32 `parquet:"position_y"`
+
+	AbilityHaste    int32 `parquet:"ability_haste"`
+	AbilityPower    int32 `parquet:"ability_power"`
+	Armor           int32 `parquet:"armor"`
+	AttackDamage    int32 `parquet:"attack_damage"`
+	AttackSpeed     int32 `parquet:"attack_speed"`
+	Health          int32 `parquet:"health"`
+	HealthMax       int32 `parquet:"health_max"`
+	MagicResist     int32 `parquet:"magic_resist"`
+	MovementSpeed   int32 `parquet:"movement_speed"`
+	Power           int32 `parquet:"power"`
+	PowerMax        int32 `parquet:"power_max"`
+	MagicPen        int32 `parquet:"magic_pen"`
+	ArmorPen        int32 `parquet:"armor_pen"`
+	Lifesteal       int32 `parquet:"lifesteal"`
+	Omnivamp        int32 `parquet:"omnivamp"`
+	CCReduction     int32 `parquet:"cc_reduction"`
+	HealthRegen     int32 `parquet:"health_regen"`
+	PowerRegen      int32 `parquet:"power_regen"`
+	PhysicalVamp    int32 `parquet:"physical_vamp"`
+	SpellVamp       int32 `parquet:"spell_vamp"`
+	MagicPenPercent int32 `parquet:"magic_pen_percent"`
+	ArmorPenPercent int32 `parquet:"armor_pen_percent"`
+
+	MagicDamageDone               int32 `parquet:"magic_damage_done"`
+	MagicDamageDoneToChampions    int32 `parquet:"magic_damage_done_to_champions"`
+	MagicDamageTaken              int32 `parquet:"magic_damage_taken"`
+	PhysicalDamageDone            int32 `parquet:"physical_damage_done"`
+	PhysicalDamageDoneToChampions int32 `parquet:"physical_damage_done_to_champions"`
+	PhysicalDamageTaken           int32 `parquet:"physical_damage_taken"`
+	TrueDamageDone                int32 `parquet:"true_damage_done"`
+	TrueDamageDoneToChampions     int32 `parquet:"true_damage_done_to_champions"`
+	TrueDamageTaken               int32 `parquet:"true_damage_taken"`
+	TotalDamageDone               int32 `parquet:"total_damage_done"`
+	TotalDamageDoneToChampions    int32 `parquet:"total_damage_done_to_champions"`
+	TotalDamageTaken              int32 `parquet:"total_damage_taken"`
+}
+
+// EventRow is one timeline event. Columns that do not apply to an event's
+// type are 0 or empty; for example only CHAMPION_KILL events have
+// killer_id, victim_id and assisting_participant_ids. The per-hit damage
+// breakdown of kills (victimDamageDealt and victimDamageReceived) is not
+// included.
+type EventRow struct {
+	MatchID    string `parquet:"match_id,dict"`
+	GameID     int64  `parquet:"game_id"`
+	FrameIndex int32  `parquet:"frame_index"`
+	// EventIndex orders the events within a frame.
+	EventIndex      int32  `parquet:"event_index"`
+	TimestampMs     int64  `parquet:"timestamp_ms"`
+	RealTimestampMs int64  `parquet:"real_timestamp_ms"`
+	Type            string `parquet:"type,dict"`
+
+	ParticipantID           int32   `parquet:"participant_id"`
+	KillerID                int32   `parquet:"killer_id"`
+	VictimID                int32   `parquet:"victim_id"`
+	AssistingParticipantIDs []int32 `parquet:"assisting_participant_ids,list"`
+	CreatorID               int32   `parquet:"creator_id"`
+	TeamID                  int32   `parquet:"team_id"`
+	PositionX               int32   `parquet:"position_x"`
+	PositionY               int32   `parquet:"position_y"`
+
+	ItemID           int32  `parquet:"item_id"`
+	AfterID          int32  `parquet:"after_id"`
+	BeforeID         int32  `parquet:"before_id"`
+	GoldGain         int32  `parquet:"gold_gain"`
+	SkillSlot        int32  `parquet:"skill_slot"`
+	LevelUpType      string `parquet:"level_up_type,dict"`
+	Level            int32  `parquet:"level"`
+	WardType         string `parquet:"ward_type,dict"`
+	BountyLevel      int32  `parquet:"bounty_level"`
+	KillStreakLength int32  `parquet:"kill_streak_length"`
+	KillType         string `parquet:"kill_type,dict"`
+	LaneType         string `parquet:"lane_type,dict"`
+	MonsterType      string `parquet:"monster_type,dict"`
+	MonsterSubType   string `parquet:"monster_sub_type,dict"`
+	BuildingType     string `parquet:"building_type,dict"`
+	TowerType        string `parquet:"tower_type,dict"`
+	WinningTeam      int32  `parquet:"winning_team"`
+}
+
+// FlattenTimeline returns the frame and event rows of a timeline, ordered by
+// frame, then participant or event.
+func FlattenTimeline(timeline *types.MatchTimeline) ([]FrameRow, []EventRow) {
+	matchID := timeline.Metadata.MatchID
+	gameID := timeline.Info.GameID
+	puuids := make(map[int]string, len(timeline.Info.Participants))
+	for _, p := range timeline.Info.Participants {
+		puuids[p.ParticipantID] = p.PUUID.Value
+	}
+
+	var frames []FrameRow
+	var events []EventRow
+	for i, frame := range timeline.Info.Frames {
+		for id, pf := range frame.Participants() {
+			frames = append(frames, frameRow(matchID, gameID, i, frame.Timestamp, id, puuids[id], pf))
+		}
+		for j, event := range frame.Events {
+			events = append(events, eventRow(matchID, gameID, i, j, event))
+		}
+	}
+	return frames, events
+}
+
+func frameRow(matchID string, gameID int64, index int, timestamp int, id int, puuid string, pf types.TimelineParticipantFrame) FrameRow {
+	team := int32(100)
+	if id > 5 {
+		team = 200
+	}
+	cs, ds := pf.ChampionStats, pf.DamageStats
+	return FrameRow{
+		MatchID:       matchID,
+		GameID:        gameID,
+		FrameIndex:    int32(index),
+		TimestampMs:   int64(timestamp),
+		ParticipantID: int32(id),
+		PUUID:         puuid,
+		TeamID:        team,
+
+		Level:                    int32(pf.Level),
+		XP:                       int32(pf.XP),
+		CurrentGold:              int32(pf.CurrentGold),
+		TotalGold:                int32(pf.TotalGold),
+		GoldPerSecond:            int32(pf.GoldPerSecond),
+		MinionsKilled:            int32(pf.MinionsKilled),
+		JungleMinionsKilled:      int32(pf.JungleMinionsKilled),
+		TimeEnemySpentControlled: int32(pf.TimeEnemySpentControlled),
+		PositionX:                int32(pf.Position.X),
+		PositionY:                int32(pf.Position.Y),
+
+		AbilityHaste:    int32(cs.AbilityHaste),
+		AbilityPower:    int32(cs.AbilityPower),
+		Armor:           int32(cs.Armor),
+		AttackDamage:    int32(cs.AttackDamage),
+		AttackSpeed:     int32(cs.AttackSpeed),
+		Health:          int32(cs.Health),
+		HealthMax:       int32(cs.HealthMax),
+		MagicResist:     int32(cs.MagicResist),
+		MovementSpeed:   int32(cs.MovementSpeed),
+		Power:           int32(cs.Power),
+		PowerMax:        int32(cs.PowerMax),
+		MagicPen:        int32(cs.MagicPen),
+		ArmorPen:        int32(cs.ArmorPen),
+		Lifesteal:       int32(cs.Lifesteal),
+		Omnivamp:        int32(cs.Omnivamp),
+		CCReduction:     int32(cs.CCReduction),
+		HealthRegen:     int32(cs.HealthRegen),
+		PowerRegen:      int32(cs.PowerRegen),
+		PhysicalVamp:    int32(cs.PhysicalVamp),
+		SpellVamp:       int32(cs.SpellVamp),
+		MagicPenPercent: int32(cs.MagicPenPercent),
+		ArmorPenPercent: int32(cs.ArmorPenPercent),
+
+		MagicDamageDone:               int32(ds.MagicDamageDone),
+		MagicDamageDoneToChampions:    int32(ds.MagicDamageDoneToChampions),
+		MagicDamageTaken:              int32(ds.MagicDamageTaken),
+		PhysicalDamageDone:            int32(ds.PhysicalDamageDone),
+		PhysicalDamageDoneToChampions: int32(ds.PhysicalDamageDoneToChampions),
+		PhysicalDamageTaken:           int32(ds.PhysicalDamageTaken),
+		TrueDamageDone:                int32(ds.TrueDamageDone),
+		TrueDamageDoneToChampions:     int32(ds.TrueDamageDoneToChampions),
+		TrueDamageTaken:               int32(ds.TrueDamageTaken),
+		TotalDamageDone:               int32(ds.TotalDamageDone),
+		TotalDamageDoneToChampions:    int32(ds.TotalDamageDoneToChampions),
+		TotalDamageTaken:              int32(ds.TotalDamageTaken),
+	}
+}
+
+func eventRow(matchID string, gameID int64, frame int, index int, e types.TimelineEvent) EventRow {
+	var assists []int32
+	for _, id := range e.AssistingParticipantIDs {
+		assists = append(assists, int32(id))
+	}
+	return EventRow{
+		MatchID:         matchID,
+		GameID:          gameID,
+		FrameIndex:      int32(frame),
+		EventIndex:      int32(index),
+		TimestampMs:     int64(e.Timestamp),
+		RealTimestampMs: e.RealTimestamp,
+		Type:            e.Type,
+
+		ParticipantID:           int32(e.ParticipantID),
+		KillerID:                int32(e.KillerID),
+		VictimID:                int32(e.VictimID),
+		AssistingParticipantIDs: assists,
+		CreatorID:               int32(e.CreatorID),
+		TeamID:                  int32(e.TeamID),
+		PositionX:               int32(e.Position.X),
+		PositionY:               int32(e.Position.Y),
+
+		ItemID:           int32(e.ItemID),
+		AfterID:          int32(e.AfterID),
+		BeforeID:         int32(e.BeforeID),
+		GoldGain:         int32(e.GoldGain),
+		SkillSlot:        int32(e.SkillSlot),
+		LevelUpType:      e.LevelUpType,
+		Level:            int32(e.Level),
+		WardType:         e.WardType,
+		BountyLevel:      int32(e.BountyLevel),
+		KillStreakLength: int32(e.KillStreakLength),
+		KillType:         e.KillType,
+		LaneType:         e.LaneType,
+		MonsterType:      e.MonsterType,
+		MonsterSubType:   e.MonsterSubType,
+		BuildingType:     e.BuildingType,
+		TowerType:        e.TowerType,
+		WinningTeam:      int32(e.WinningTeam),
+	}
+}
+
+// TimelineWriter flattens timelines and appends their rows to a frames and
+// an events Parquet file. Rows are buffered in row groups and only readable
+// once the writer is closed. It is safe for concurrent use.
+//
+// Load the files with e.g. pandas.read_parquet("frames.parquet") or
+// duckdb's SELECT * FROM 'events.parquet'.
+type TimelineWriter struct {
+	mu     sync.Mutex
+	frames *parquet.GenericWriter[FrameRow]
+	events *parquet.GenericWriter[EventRow]
+	closer []io.Closer
+}
+
+// NewTimelineWriter writes frame rows to frames and event rows to events,
+// compressed with zstd.
+func NewTimelineWriter(frames io.Writer, events io.Writer) *TimelineWriter {
+	return &TimelineWriter{
+		frames: parquet.NewGenericWriter[FrameRow](frames, parquet.Compression(&parquet.Zstd)),
+		events: parquet.NewGenericWriter[EventRow](events, parquet.Compression(&parquet.Zstd)),
+	}
+}
+
+// CreateTimelineFiles creates dir/frames.parquet and dir/events.parquet,
+// replacing existing files, and returns a writer for them.
+func CreateTimelineFiles(dir string) (*TimelineWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	frames, err := os.Create(filepath.Join(dir, "frames.parquet"))
+	if err != nil {
+		return nil, err
+	}
+	events, err := os.Create(filepath.Join(dir, "events.parquet"))
+	if err != nil {
+		frames.Close()
+		return nil, err
+	}
+	w := NewTimelineWriter(frames, events)
+	w.closer = []io.Closer{frames, events}
+	return w, nil
+}
+
+// Put appends the rows of timeline.
+func (w *TimelineWriter) Put(ctx context.Context, timeline *types.MatchTimeline) error {
+	frames, events := FlattenTimeline(timeline)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.frames.Write(frames); err != nil {
+		return err
+	}
+	_, err := w.events.Write(events)
+	return err
+}
+
+// Close writes the Parquet footers and closes files opened by
+// CreateTimelineFiles. The files are incomplete until Close returns.
+func (w *TimelineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	errs := []error{w.frames.Close(), w.events.Close()}
+	for _, c := range w.closer {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,146 @@
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/travior/lol-sdk/riottest"
+	"github.com/travior/lol-sdk/store"
+	"github.com/travior/lol-sdk/types"
+)
+
+func fixtureTimeline(t *testing.T, id string) *types.MatchTimeline {
+	t.Helper()
+	timeline, err := riottest.FixtureTimeline(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &timeline
+}
+
+func TestFlattenTimeline(t *testing.T) {
+	timeline := fixtureTimeline(t, riottest.FixtureMatchID)
+	frames, events := store.FlattenTimeline(timeline)
+
+	if len(frames) != 40 {
+		t.Fatalf("got %d frame rows, want 4 frames x 10 participants", len(frames))
+	}
+	for i, row := range frames {
+		if want := int32(i / 10); row.FrameIndex != want {
+			t.Fatalf("row %d frame index = %d, want %d", i, row.FrameIndex, want)
+		}
+		if want := int32(i%10 + 1); row.ParticipantID != want {
+			t.Fatalf("row %d participant = %d, want %d", i, row.ParticipantID, want)
+		}
+	}
+	first := frames[10]
+	if first.MatchID != riottest.FixtureMatchID || first.PUUID != riottest.FixturePUUID || first.TeamID != 100 {
+		t.Errorf("frame 1 participant 1 = %+v", first)
+	}
+	if first.TimestampMs != 60021 {
+		t.Errorf("timestamp = %d, want 60021", first.TimestampMs)
+	}
+	if frames[15].TeamID != 200 {
+		t.Errorf("participant 6 team = %d, want 200", frames[15].TeamID)
+	}
+
+	var kill *store.EventRow
+	for i := range events {
+		if events[i].Type == "CHAMPION_KILL" {
+			kill = &events[i]
+			break
+		}
+	}
+	if kill == nil {
+		t.Fatal("no CHAMPION_KILL row")
+	}
+	if kill.FrameIndex != 1 || kill.KillerID != 1 || kill.VictimID != 6 ||
+		!reflect.DeepEqual(kill.AssistingParticipantIDs, []int32{2}) || kill.PositionX != 7100 {
+		t.Errorf("kill row = %+v", kill)
+	}
+}
+
+func TestFlattenTimelinePrefersFrameParticipantID(t *testing.T) {
+	var timeline types.MatchTimeline
+	timeline.Info.Participants = []types.TimelineParticipant{
+		{ParticipantID: 2, PUUID: types.NewPUUID("p-2")},
+		{ParticipantID: 7, PUUID: types.NewPUUID("p-7")},
+	}
+	timeline.Info.Frames = []types.TimelineFrame{{
+		ParticipantFrames: map[string]types.TimelineParticipantFrame{
+			// The key disagrees with the frame's own participantId.
+			"1": {ParticipantID: 7, Level: 3},
+			// No participantId, so the key is used.
+			"2": {Level: 2},
+			// Also resolves to 7, but only by its key.
+			"7": {Level: 1},
+		},
+	}}
+
+	frames, _ := store.FlattenTimeline(&timeline)
+	if len(frames) != 2 {
+		t.Fatalf("got %d frame rows, want one per participant", len(frames))
+	}
+	if got := frames[0]; got.ParticipantID != 2 || got.PUUID != "p-2" || got.Level != 2 {
+		t.Errorf("row 0 = %+v, want participant 2 from its key", got)
+	}
+	if got := frames[1]; got.ParticipantID != 7 || got.PUUID != "p-7" || got.Level != 3 || got.TeamID != 200 {
+		t.Errorf("row 1 = %+v, want participant 7 from its participantId", got)
+	}
+}
+
+func TestTimelineWriterParquet(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "timelines")
+	w, err := store.CreateTimelineFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	var wantFrames []store.FrameRow
+	var wantEvents []store.EventRow
+	for _, id := range []string{riottest.FixtureMatchID, riottest.FixtureOlderMatchID} {
+		timeline := fixtureTimeline(t, id)
+		if err := w.Put(ctx, timeline); err != nil {
+			t.Fatal(err)
+		}
+		frames, events := store.FlattenTimeline(timeline)
+		wantFrames = append(wantFrames, frames...)
+		wantEvents = append(wantEvents, events...)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	frames, err := parquet.ReadFile[store.FrameRow](filepath.Join(dir, "frames.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(frames, wantFrames) {
+		t.Errorf("read %d frame rows, want %d equal to FlattenTimeline", len(frames), len(wantFrames))
+	}
+	events, err := parquet.ReadFile[store.EventRow](filepath.Join(dir, "events.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != len(wantEvents) {
+		t.Fatalf("read %d event rows, want %d", len(events), len(wantEvents))
+	}
+	for i := range events {
+		// Empty lists read back as nil or empty depending on the reader.
+		if len(events[i].AssistingParticipantIDs) == 0 && len(wantEvents[i].AssistingParticipantIDs) == 0 {
+			events[i].AssistingParticipantIDs = wantEvents[i].AssistingParticipantIDs
+		}
+		if !reflect.DeepEqual(events[i], wantEvents[i]) {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], wantEvents[i])
+		}
+	}
+
+	schema := parquet.SchemaOf(store.EventRow{})
+	if _, ok := schema.Lookup("assisting_participant_ids", "list", "element"); !ok {
+		t.Errorf("events schema has no assisting_participant_ids list:\n%s", schema)
+	}
+}
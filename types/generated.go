@@ -0,0 +1,67 @@
+// Code generated by lolgen. DO NOT EDIT.
+
+package types
+
+// Challenges holds the challenge statistics of a participant. Matches played
+// before challenges were introduced leave it empty.
+type Challenges struct {
+	AbilityUses                      int     `json:"abilityUses"`
+	AcesBefore15Minutes              int     `json:"acesBefore15Minutes"`
+	BaronTakedowns                   int     `json:"baronTakedowns"`
+	BountyGold                       float64 `json:"bountyGold"`
+	ControlWardsPlaced               int     `json:"controlWardsPlaced"`
+	DamagePerMinute                  float64 `json:"damagePerMinute"`
+	DamageTakenOnTeamPercentage      float64 `json:"damageTakenOnTeamPercentage"`
+	DragonTakedowns                  int     `json:"dragonTakedowns"`
+	EarliestBaron                    float64 `json:"earliestBaron"`
+	EffectiveHealAndShielding        float64 `json:"effectiveHealAndShielding"`
+	EnemyChampionImmobilizations     int     `json:"enemyChampionImmobilizations"`
+	GameLength                       float64 `json:"gameLength"`
+	GoldPerMinute                    float64 `json:"goldPerMinute"`
+	ImmobilizeAndKillWithAlly        int     `json:"immobilizeAndKillWithAlly"`
+	KDA                              float64 `json:"kda"`
+	KillParticipation                float64 `json:"killParticipation"`
+	KillsNearEnemyTurret             int     `json:"killsNearEnemyTurret"`
+	LaneMinionsFirst10Minutes        int     `json:"laneMinionsFirst10Minutes"`
+	MaxCsAdvantageOnLaneOpponent     float64 `json:"maxCsAdvantageOnLaneOpponent"`
+	MaxLevelLeadLaneOpponent         int     `json:"maxLevelLeadLaneOpponent"`
+	Multikills                       int     `json:"multikills"`
+	PerfectGame                      int     `json:"perfectGame"`
+	SkillshotsDodged                 int     `json:"skillshotsDodged"`
+	SkillshotsHit                    int     `json:"skillshotsHit"`
+	SoloKills                        int     `json:"soloKills"`
+	Takedowns                        int     `json:"takedowns"`
+	TeamDamagePercentage             float64 `json:"teamDamagePercentage"`
+	TurretPlatesTaken                int     `json:"turretPlatesTaken"`
+	TurretTakedowns                  int     `json:"turretTakedowns"`
+	VisionScoreAdvantageLaneOpponent float64 `json:"visionScoreAdvantageLaneOpponent"`
+	VisionScorePerMinute             float64 `json:"visionScorePerMinute"`
+	WardTakedowns                    int     `json:"wardTakedowns"`
+	WardsGuarded                     int     `json:"wardsGuarded"`
+}
+
+// ChampionMastery is the mastery of one player on one champion.
+type ChampionMastery struct {
+	ChampionID                   int64                `json:"championId"`
+	ChampionLevel                int                  `json:"championLevel"`
+	ChampionPoints               int                  `json:"championPoints"`
+	ChampionPointsSinceLastLevel int64                `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int64                `json:"championPointsUntilNextLevel"`
+	ChampionSeasonMilestone      int                  `json:"championSeasonMilestone"`
+	ChestGranted                 bool                 `json:"chestGranted"`
+	LastPlayTime                 int64                `json:"lastPlayTime"`
+	MarkRequiredForNextLevel     int                  `json:"markRequiredForNextLevel"`
+	MilestoneGrades              []string             `json:"milestoneGrades"`
+	NextSeasonMilestone          NextSeasonMilestones `json:"nextSeasonMilestone"`
+	PUUID                        PUUID                `json:"puuid"`
+	TokensEarned                 int                  `json:"tokensEarned"`
+}
+
+// NextSeasonMilestones describes the requirements of the next season
+// milestone.
+type NextSeasonMilestones struct {
+	Bonus              bool           `json:"bonus"`
+	RequireGradeCounts map[string]int `json:"requireGradeCounts"`
+	RewardMarks        int            `json:"rewardMarks"`
+	TotalGamesRequires int            `json:"totalGamesRequires"`
+}
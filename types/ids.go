@@ -0,0 +1,176 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// KeyFingerprint identifies the API key an encrypted ID was issued for
+// without revealing the key itself.
+type KeyFingerprint string
+
+// FingerprintKey derives the fingerprint of an API key.
+func FingerprintKey(apiKey string) KeyFingerprint {
+	sum := sha256.Sum256([]byte(apiKey))
+	return KeyFingerprint(hex.EncodeToString(sum[:6]))
+}
+
+// EncryptedID is an ID Riot encrypts per API key. Value is the ID as Riot
+// returns it; Key, when set, is the fingerprint of the key that produced it.
+// Only Value is used in JSON, so decoded types stay compatible with Riot's
+// responses. Use Tagged and the Parse functions to persist the fingerprint.
+type EncryptedID struct {
+	Value string
+	Key   KeyFingerprint
+}
+
+func (id EncryptedID) String() string {
+	return id.Value
+}
+
+func (id EncryptedID) IsZero() bool {
+	return id.Value == ""
+}
+
+// Tagged returns the ID with its key fingerprint appended as "value@key".
+func (id EncryptedID) Tagged() string {
+	if id.Key == "" {
+		return id.Value
+	}
+	return id.Value + "@" + string(id.Key)
+}
+
+func (id EncryptedID) MarshalText() ([]byte, error) {
+	return []byte(id.Value), nil
+}
+
+func (id *EncryptedID) UnmarshalText(text []byte) error {
+	id.Value = string(text)
+	return nil
+}
+
+func parseEncryptedID(s string) EncryptedID {
+	value, key, _ := strings.Cut(s, "@")
+	return EncryptedID{Value: value, Key: KeyFingerprint(key)}
+}
+
+type PUUID struct{ EncryptedID }
+
+type SummonerID struct{ EncryptedID }
+
+type AccountID struct{ EncryptedID }
+
+func NewPUUID(value string) PUUID {
+	return PUUID{EncryptedID{Value: value}}
+}
+
+func NewSummonerID(value string) SummonerID {
+	return SummonerID{EncryptedID{Value: value}}
+}
+
+func NewAccountID(value string) AccountID {
+	return AccountID{EncryptedID{Value: value}}
+}
+
+// ParsePUUID parses a PUUID in plain or Tagged form.
+func ParsePUUID(s string) PUUID {
+	return PUUID{parseEncryptedID(s)}
+}
+
+// ParseSummonerID parses a summoner ID in plain or Tagged form.
+func ParseSummonerID(s string) SummonerID {
+	return SummonerID{parseEncryptedID(s)}
+}
+
+// ParseAccountID parses an account ID in plain or Tagged form.
+func ParseAccountID(s string) AccountID {
+	return AccountID{parseEncryptedID(s)}
+}
+
+var encryptedIDType = reflect.TypeFor[EncryptedID]()
+
+// TagIDs sets key on every non-empty encrypted ID reachable from v that does
+// not carry a fingerprint yet. v must be a pointer.
+func TagIDs(v any, key KeyFingerprint) {
+	if key == "" {
+		return
+	}
+	tagIDs(reflect.ValueOf(v), key)
+}
+
+func tagIDs(v reflect.Value, key KeyFingerprint) {
+	if !v.IsValid() || !containsID(v.Type()) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			tagIDs(v.Elem(), key)
+		}
+	case reflect.Struct:
+		if v.Type() == encryptedIDType {
+			if v.CanSet() && v.Field(0).String() != "" && v.Field(1).String() == "" {
+				v.Field(1).SetString(string(key))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				tagIDs(v.Field(i), key)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			tagIDs(v.Index(i), key)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			tagIDs(elem, key)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+	}
+}
+
+var containsIDCache sync.Map // map[reflect.Type]bool
+
+// containsID reports whether values of type t can hold an EncryptedID, so
+// that TagIDs can skip large ID-free parts such as timeline frames.
+func containsID(t reflect.Type) bool {
+	if cached, ok := containsIDCache.Load(t); ok {
+		return cached.(bool)
+	}
+	found := searchID(t, map[reflect.Type]bool{})
+	containsIDCache.Store(t, found)
+	return found
+}
+
+func searchID(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == encryptedIDType {
+		return true
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return searchID(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && searchID(t.Field(i).Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEncryptedIDsDecodeAsPlainStrings(t *testing.T) {
+	var entry LeagueEntry
+	if err := json.Unmarshal([]byte(`{"summonerId":"s1","puuid":"p1"}`), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.PUUID.Value != "p1" || entry.SummonerID.Value != "s1" {
+		t.Fatalf("unexpected IDs %+v", entry)
+	}
+
+	entry.PUUID.Key = "abc"
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip map[string]any
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if roundTrip["puuid"] != "p1" {
+		t.Errorf("expected PUUID to marshal as plain string, got %v", roundTrip["puuid"])
+	}
+}
+
+func TestTagIDs(t *testing.T) {
+	match := Match{
+		Metadata: MatchMetadata{Participants: []PUUID{NewPUUID("p1"), NewPUUID("p2")}},
+		Info: MatchInfo{Participants: []Participant{
+			{PUUID: NewPUUID("p1"), SummonerID: NewSummonerID("s1")},
+			{PUUID: PUUID{EncryptedID{Value: "p2", Key: "other"}}},
+		}},
+	}
+	TagIDs(&match, "fp")
+
+	if match.Metadata.Participants[0].Key != "fp" || match.Info.Participants[0].SummonerID.Key != "fp" {
+		t.Errorf("IDs were not tagged: %+v", match)
+	}
+	if match.Info.Participants[1].PUUID.Key != "other" {
+		t.Error("existing fingerprint was overwritten")
+	}
+	if match.Info.Participants[1].SummonerID.Key != "" {
+		t.Error("empty ID was tagged")
+	}
+}
+
+func TestTaggedRoundTrip(t *testing.T) {
+	id := PUUID{EncryptedID{Value: "abc-DEF_1", Key: FingerprintKey("RGAPI-x")}}
+	if parsed := ParsePUUID(id.Tagged()); parsed != id {
+		t.Errorf("got %+v, want %+v", parsed, id)
+	}
+	if parsed := ParsePUUID("plain"); parsed != NewPUUID("plain") {
+		t.Errorf("got %+v for untagged ID", parsed)
+	}
+}
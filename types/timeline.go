@@ -0,0 +1,162 @@
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// participantFrames resolves the participant ID of every participant frame.
+// The frame's own participantId wins over its map key, which is only used
+// for frames that lack one. If several frames resolve to the same ID, the
+// one carrying it as participantId is kept, then the one keyed by it, then
+// the one with the smallest key.
+func (f TimelineFrame) participantFrames() map[int]TimelineParticipantFrame {
+	type resolved struct {
+		key   string
+		frame TimelineParticipantFrame
+	}
+	rank := func(id int, r resolved) int {
+		switch {
+		case r.frame.ParticipantID == id:
+			return 0
+		case r.key == strconv.Itoa(id):
+			return 1
+		}
+		return 2
+	}
+	byID := make(map[int]resolved, len(f.ParticipantFrames))
+	for key, pf := range f.ParticipantFrames {
+		id := pf.ParticipantID
+		if id == 0 {
+			var err error
+			if id, err = strconv.Atoi(key); err != nil {
+				continue
+			}
+		}
+		r := resolved{key, pf}
+		if prev, ok := byID[id]; ok {
+			if c := cmp.Compare(rank(id, prev), rank(id, r)); c < 0 || c == 0 && prev.key < key {
+				continue
+			}
+		}
+		byID[id] = r
+	}
+	frames := make(map[int]TimelineParticipantFrame, len(byID))
+	for id, r := range byID {
+		frames[id] = r.frame
+	}
+	return frames
+}
+
+// Participant returns the frame of the participant with the given ID.
+func (f TimelineFrame) Participant(id int) (TimelineParticipantFrame, bool) {
+	pf, ok := f.participantFrames()[id]
+	return pf, ok
+}
+
+// ParticipantIDs returns the IDs of the participants in the frame, in
+// ascending order. A frame's ID is its participantId, or its map key if it
+// has none; keys that are not numbers are skipped.
+func (f TimelineFrame) ParticipantIDs() []int {
+	return slices.Sorted(maps.Keys(f.participantFrames()))
+}
+
+// Participants iterates over the participant frames by participant ID, in
+// ascending order. Each ID is yielded once, as described on ParticipantIDs.
+func (f TimelineFrame) Participants() iter.Seq2[int, TimelineParticipantFrame] {
+	return func(yield func(int, TimelineParticipantFrame) bool) {
+		frames := f.participantFrames()
+		for _, id := range slices.Sorted(maps.Keys(frames)) {
+			if !yield(id, frames[id]) {
+				return
+			}
+		}
+	}
+}
+
+// ParticipantID returns the participant ID of puuid in the timeline.
+func (t *MatchTimeline) ParticipantID(puuid PUUID) (int, bool) {
+	for _, p := range t.Info.Participants {
+		if p.PUUID.Value == puuid.Value {
+			return p.ParticipantID, true
+		}
+	}
+	return 0, false
+}
+
+// PUUID returns the PUUID of the participant with the given ID.
+func (t *MatchTimeline) PUUID(participantID int) (PUUID, bool) {
+	for _, p := range t.Info.Participants {
+		if p.ParticipantID == participantID {
+			return p.PUUID, true
+		}
+	}
+	return PUUID{}, false
+}
+
+// ParticipantFrames returns the frames of the participant with the given
+// ID, one per timeline frame in order. Frames missing the participant
+// are skipped.
+func (t *MatchTimeline) ParticipantFrames(participantID int) []TimelineParticipantFrame {
+	var frames []TimelineParticipantFrame
+	for _, frame := range t.Info.Frames {
+		if pf, ok := frame.Participant(participantID); ok {
+			frames = append(frames, pf)
+		}
+	}
+	return frames
+}
+
+// ParticipantFramesByPUUID is like ParticipantFrames for the participant
+// with the given PUUID. It returns nil if puuid is not in the timeline.
+func (t *MatchTimeline) ParticipantFramesByPUUID(puuid PUUID) []TimelineParticipantFrame {
+	id, ok := t.ParticipantID(puuid)
+	if !ok {
+		return nil
+	}
+	return t.ParticipantFrames(id)
+}
+
+// TimelineParticipantView joins a timeline participant to its match
+// participant and frames.
+type TimelineParticipantView struct {
+	// ParticipantID is the ID in the timeline, which keys its frames and
+	// events.
+	ParticipantID int
+	PUUID         PUUID
+	// Participant is the match participant with the same PUUID, or nil if
+	// the match has none.
+	Participant *Participant
+	Frames      []TimelineParticipantFrame
+}
+
+// JoinParticipants links the participants of t to those of match by PUUID,
+// in ascending participant ID order. It fails if the timeline belongs to
+// another match.
+func (t *MatchTimeline) JoinParticipants(match *Match) ([]TimelineParticipantView, error) {
+	if t.Metadata.MatchID != match.Metadata.MatchID {
+		return nil, fmt.Errorf("timeline of %s joined with match %s", t.Metadata.MatchID, match.Metadata.MatchID)
+	}
+	byPUUID := make(map[string]*Participant, len(match.Info.Participants))
+	for i := range match.Info.Participants {
+		p := &match.Info.Participants[i]
+		byPUUID[p.PUUID.Value] = p
+	}
+	views := make([]TimelineParticipantView, 0, len(t.Info.Participants))
+	for _, p := range t.Info.Participants {
+		views = append(views, TimelineParticipantView{
+			ParticipantID: p.ParticipantID,
+			PUUID:         p.PUUID,
+			Participant:   byPUUID[p.PUUID.Value],
+			Frames:        t.ParticipantFrames(p.ParticipantID),
+		})
+	}
+	slices.SortFunc(views, func(a, b TimelineParticipantView) int {
+		return cmp.Compare(a.ParticipantID, b.ParticipantID)
+	})
+	return views, nil
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func testTimeline(t *testing.T) *MatchTimeline {
+	t.Helper()
+	var timeline MatchTimeline
+	err := json.Unmarshal([]byte(`{
+		"metadata": {"matchId": "EUW1_1", "participants": ["p-a", "p-b", "p-c"]},
+		"info": {
+			"participants": [
+				{"participantId": 10, "puuid": "p-c"},
+				{"participantId": 2, "puuid": "p-b"},
+				{"participantId": 1, "puuid": "p-a"}
+			],
+			"frames": [
+				{"timestamp": 0, "participantFrames": {
+					"10": {"participantId": 10, "totalGold": 500},
+					"2": {"participantId": 2, "totalGold": 500},
+					"1": {"participantId": 1, "totalGold": 500}
+				}},
+				{"timestamp": 60000, "participantFrames": {
+					"10": {"participantId": 10, "totalGold": 900},
+					"1": {"participantId": 1, "totalGold": 800}
+				}}
+			]
+		}
+	}`), &timeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &timeline
+}
+
+func TestTimelineFrameParticipants(t *testing.T) {
+	frame := testTimeline(t).Info.Frames[0]
+	if got := frame.ParticipantIDs(); !slices.Equal(got, []int{1, 2, 10}) {
+		t.Errorf("ParticipantIDs = %v, want numeric order", got)
+	}
+	var ids []int
+	for id, pf := range frame.Participants() {
+		if pf.ParticipantID != id {
+			t.Errorf("participant %d yielded frame of %d", id, pf.ParticipantID)
+		}
+		ids = append(ids, id)
+	}
+	if !slices.Equal(ids, []int{1, 2, 10}) {
+		t.Errorf("Participants yielded %v", ids)
+	}
+	if pf, ok := frame.Participant(10); !ok || pf.TotalGold != 500 {
+		t.Errorf("Participant(10) = %+v, %v", pf, ok)
+	}
+	if _, ok := frame.Participant(3); ok {
+		t.Error("Participant(3) found a missing participant")
+	}
+}
+
+func TestTimelineFrameParticipantIDPrecedence(t *testing.T) {
+	frame := TimelineFrame{ParticipantFrames: map[string]TimelineParticipantFrame{
+		// The key disagrees with the frame's own participantId.
+		"1": {ParticipantID: 7, Level: 3},
+		// No participantId, so the key is used.
+		"2": {Level: 2},
+		// Both resolve to 4; the one carrying participantId 4 wins.
+		"4": {Level: 1},
+		"5": {ParticipantID: 4, Level: 4},
+		"x": {Level: 9},
+	}}
+	if got := frame.ParticipantIDs(); !slices.Equal(got, []int{2, 4, 7}) {
+		t.Errorf("ParticipantIDs = %v, want [2 4 7]", got)
+	}
+	var levels []int
+	for _, pf := range frame.Participants() {
+		levels = append(levels, pf.Level)
+	}
+	if !slices.Equal(levels, []int{2, 4, 3}) {
+		t.Errorf("Participants yielded levels %v, want [2 4 3]", levels)
+	}
+	if pf, ok := frame.Participant(7); !ok || pf.Level != 3 {
+		t.Errorf("Participant(7) = %+v, %v", pf, ok)
+	}
+	if _, ok := frame.Participant(1); ok {
+		t.Error("Participant(1) found a frame that belongs to participant 7")
+	}
+}
+
+func TestMatchTimelineByPUUID(t *testing.T) {
+	timeline := testTimeline(t)
+	if id, ok := timeline.ParticipantID(NewPUUID("p-c")); !ok || id != 10 {
+		t.Errorf("ParticipantID(p-c) = %d, %v", id, ok)
+	}
+	if puuid, ok := timeline.PUUID(2); !ok || puuid.Value != "p-b" {
+		t.Errorf("PUUID(2) = %v, %v", puuid, ok)
+	}
+	frames := timeline.ParticipantFramesByPUUID(NewPUUID("p-c"))
+	if len(frames) != 2 || frames[1].TotalGold != 900 {
+		t.Errorf("frames of p-c = %+v", frames)
+	}
+	if frames := timeline.ParticipantFrames(2); len(frames) != 1 {
+		t.Errorf("got %d frames of participant 2, want the one it appears in", len(frames))
+	}
+	if frames := timeline.ParticipantFramesByPUUID(NewPUUID("nobody")); frames != nil {
+		t.Errorf("frames of an unknown PUUID = %+v", frames)
+	}
+}
+
+func TestJoinParticipants(t *testing.T) {
+	timeline := testTimeline(t)
+	var match Match
+	match.Metadata.MatchID = "EUW1_1"
+	match.Info.Participants = []Participant{
+		{ParticipantID: 1, PUUID: NewPUUID("p-b"), ChampionName: "Ahri"},
+		{ParticipantID: 2, PUUID: NewPUUID("p-a"), ChampionName: "Garen"},
+	}
+
+	views, err := timeline.JoinParticipants(&match)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(views) != 3 {
+		t.Fatalf("got %d views, want 3", len(views))
+	}
+	// The join is by PUUID, not by participant ID.
+	if views[0].ParticipantID != 1 || views[0].Participant == nil || views[0].Participant.ChampionName != "Garen" {
+		t.Errorf("view 0 = %+v", views[0])
+	}
+	if views[1].Participant == nil || views[1].Participant.ChampionName != "Ahri" {
+		t.Errorf("view 1 = %+v", views[1])
+	}
+	if views[2].ParticipantID != 10 || views[2].Participant != nil || len(views[2].Frames) != 2 {
+		t.Errorf("view 2 = %+v, want no match participant and two frames", views[2])
+	}
+
+	match.Metadata.MatchID = "EUW1_2"
+	if _, err := timeline.JoinParticipants(&match); err == nil {
+		t.Error("joining another match's timeline succeeded")
+	}
+}
@@ -85,24 +85,49 @@ func (r Region) ToString() string {
 	return ""
 }
 
+// DecodeWarning describes a response value that did not fit the field it was
+// decoded into and was left at its zero value instead.
+type DecodeWarning struct {
+	Path     string
+	Expected string
+	Got      string
+}
+
+func (w DecodeWarning) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", w.Path, w.Expected, w.Got)
+}
+
+// Account is a Riot account, shared by all Riot games. Its Riot ID is
+// GameName#TagLine.
+type Account struct {
+	PUUID    PUUID  `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
 type Summoner struct {
-	ID            string `json:"id"`
-	AccountID     string `json:"accountId"`
-	PUUID         string `json:"puuid"`
-	Name          string `json:"name"`
-	ProfileIconID int    `json:"profileIconId"`
-	RevisionDate  int64  `json:"revisionDate"`
-	SummonerLevel int    `json:"summonerLevel"`
+	ID            SummonerID `json:"id"`
+	AccountID     AccountID  `json:"accountId"`
+	PUUID         PUUID      `json:"puuid"`
+	Name          string     `json:"name"`
+	ProfileIconID int        `json:"profileIconId"`
+	RevisionDate  int64      `json:"revisionDate"`
+	SummonerLevel int        `json:"summonerLevel"`
+
+	DecodeWarnings []DecodeWarning `json:"-"`
 }
+
 type Match struct {
 	Metadata MatchMetadata `json:"metadata"`
 	Info     MatchInfo     `json:"info"`
+
+	DecodeWarnings []DecodeWarning `json:"-"`
 }
 
 type MatchMetadata struct {
-	DataVersion  string   `json:"dataVersion"`
-	MatchID      string   `json:"matchId"`
-	Participants []string `json:"participants"`
+	DataVersion  string  `json:"dataVersion"`
+	MatchID      string  `json:"matchId"`
+	Participants []PUUID `json:"participants"`
 }
 
 type MatchInfo struct {
@@ -129,6 +154,7 @@ type Participant struct {
 	Assists                        int              `json:"assists"`
 	BaronKills                     int              `json:"baronKills"`
 	BountyLevel                    int              `json:"bountyLevel"`
+	Challenges                     Challenges       `json:"challenges"`
 	ChampExperience                int              `json:"champExperience"`
 	ChampLevel                     int              `json:"champLevel"`
 	ChampionID                     int              `json:"championId"`
@@ -194,7 +220,7 @@ type Participant struct {
 	PhysicalDamageTaken            int              `json:"physicalDamageTaken"`
 	ProfileIcon                    int              `json:"profileIcon"`
 	PushPings                      int              `json:"pushPings"`
-	PUUID                          string           `json:"puuid"`
+	PUUID                          PUUID            `json:"puuid"`
 	QuadraKills                    int              `json:"quadraKills"`
 	RiotIDGameName                 string           `json:"riotIdGameName"`
 	RiotIDName                     string           `json:"riotIdName"`
@@ -209,7 +235,7 @@ type Participant struct {
 	Summoner1ID                    int              `json:"summoner1Id"`
 	Summoner2Casts                 int              `json:"summoner2Casts"`
 	Summoner2ID                    int              `json:"summoner2Id"`
-	SummonerID                     string           `json:"summonerId"`
+	SummonerID                     SummonerID       `json:"summonerId"`
 	SummonerLevel                  int              `json:"summonerLevel"`
 	SummonerName                   string           `json:"summonerName"`
 	TeamEarlySurrendered           bool             `json:"teamEarlySurrendered"`
@@ -296,12 +322,14 @@ type TeamObjective struct {
 type MatchTimeline struct {
 	Metadata TimelineMetadata `json:"metadata"`
 	Info     TimelineInfo     `json:"info"`
+
+	DecodeWarnings []DecodeWarning `json:"-"`
 }
 
 type TimelineMetadata struct {
-	DataVersion  string   `json:"dataVersion"`
-	MatchID      string   `json:"matchId"`
-	Participants []string `json:"participants"`
+	DataVersion  string  `json:"dataVersion"`
+	MatchID      string  `json:"matchId"`
+	Participants []PUUID `json:"participants"`
 }
 
 type TimelineInfo struct {
@@ -426,8 +454,8 @@ type TimelineDamageStats struct {
 }
 
 type TimelineParticipant struct {
-	ParticipantID int    `json:"participantId"`
-	PUUID         string `json:"puuid"`
+	ParticipantID int   `json:"participantId"`
+	PUUID         PUUID `json:"puuid"`
 }
 
 type LeagueList struct {
@@ -436,12 +464,19 @@ type LeagueList struct {
 	Tier     string        `json:"tier"`
 	Name     string        `json:"name"`
 	Queue    string        `json:"queue"`
+
+	DecodeWarnings []DecodeWarning `json:"-"`
 }
 
 type LeagueEntry struct {
-	SummonerID   string     `json:"summonerId"`
+	// LeagueID, QueueType and Tier are only set by the entries endpoints;
+	// entries of a LeagueList leave them empty.
+	LeagueID     string     `json:"leagueId"`
+	QueueType    string     `json:"queueType"`
+	Tier         string     `json:"tier"`
+	SummonerID   SummonerID `json:"summonerId"`
 	SummonerName string     `json:"summonerName"`
-	PUUID        string     `json:"puuid"`
+	PUUID        PUUID      `json:"puuid"`
 	LeaguePoints int        `json:"leaguePoints"`
 	Rank         string     `json:"rank"`
 	Wins         int        `json:"wins"`
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GameVersion is a League client version such as "14.19.621.1234", as found
+// in MatchInfo.GameVersion. Its Patch is the version players refer to,
+// "14.19". The zero GameVersion means unknown or unbounded.
+type GameVersion struct {
+	Major    int
+	Minor    int
+	Build    int
+	Revision int
+}
+
+// ParseGameVersion parses a version of two to four dot-separated numbers,
+// e.g. "14.19" or "14.19.621.1234".
+func ParseGameVersion(s string) (GameVersion, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 4 {
+		return GameVersion{}, fmt.Errorf("invalid game version %q", s)
+	}
+	var numbers [4]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return GameVersion{}, fmt.Errorf("invalid game version %q", s)
+		}
+		numbers[i] = n
+	}
+	return GameVersion{Major: numbers[0], Minor: numbers[1], Build: numbers[2], Revision: numbers[3]}, nil
+}
+
+// MustParseGameVersion is like ParseGameVersion but panics on invalid
+// input. It is meant for constants such as MustParseGameVersion("14.19").
+func MustParseGameVersion(s string) GameVersion {
+	v, err := ParseGameVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Patch returns the major.minor part of the version.
+func (v GameVersion) Patch() GameVersion {
+	return GameVersion{Major: v.Major, Minor: v.Minor}
+}
+
+func (v GameVersion) IsZero() bool {
+	return v == GameVersion{}
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is older than, equal
+// to or newer than w. Compare patches with v.Patch().Compare(w.Patch()).
+func (v GameVersion) Compare(w GameVersion) int {
+	if c := cmp.Compare(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Build, w.Build); c != 0 {
+		return c
+	}
+	return cmp.Compare(v.Revision, w.Revision)
+}
+
+func (v GameVersion) Before(w GameVersion) bool {
+	return v.Compare(w) < 0
+}
+
+// InPatchRange reports whether the patch of v lies within the patches of
+// from and to, inclusive. A zero bound is unbounded.
+func (v GameVersion) InPatchRange(from GameVersion, to GameVersion) bool {
+	patch := v.Patch()
+	if !from.IsZero() && patch.Before(from.Patch()) {
+		return false
+	}
+	if !to.IsZero() && to.Patch().Before(patch) {
+		return false
+	}
+	return true
+}
+
+// String formats the version as major.minor, followed by build and revision
+// if either is set.
+func (v GameVersion) String() string {
+	if v.Build == 0 && v.Revision == 0 {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("%d.%d.%d.%d", v.Major, v.Minor, v.Build, v.Revision)
+}
+
+func (v GameVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *GameVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseGameVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+// Version parses GameVersion, returning the zero version if Riot sent
+// something unparseable.
+func (i MatchInfo) Version() GameVersion {
+	v, _ := ParseGameVersion(i.GameVersion)
+	return v
+}
+
+// Patch returns the patch the match was played on, e.g. 14.19.
+func (i MatchInfo) Patch() GameVersion {
+	return i.Version().Patch()
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseGameVersion(t *testing.T) {
+	v, err := ParseGameVersion("14.19.621.1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != (GameVersion{14, 19, 621, 1234}) {
+		t.Fatalf("unexpected version %+v", v)
+	}
+	if got := v.Patch().String(); got != "14.19" {
+		t.Errorf("expected patch 14.19, got %s", got)
+	}
+	if got := v.String(); got != "14.19.621.1234" {
+		t.Errorf("expected the version to round-trip, got %s", got)
+	}
+
+	for _, bad := range []string{"", "14", "14.x", "1.2.3.4.5", "14.-1"} {
+		if _, err := ParseGameVersion(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestGameVersionCompare(t *testing.T) {
+	ordered := []string{"13.24.1", "14.1", "14.1.500", "14.9.1", "14.10", "14.19.621.1234", "14.19.621.1300"}
+	for i := range ordered[:len(ordered)-1] {
+		a, b := MustParseGameVersion(ordered[i]), MustParseGameVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 || !a.Before(b) {
+			t.Errorf("expected %s before %s", a, b)
+		}
+	}
+
+	v := MustParseGameVersion("14.19.621.1234")
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{"14.19", "14.19", true},
+		{"14.19", "", true},
+		{"", "14.18", false},
+		{"14.20", "", false},
+		{"14.1", "14.20", true},
+	}
+	for _, c := range cases {
+		var from, to GameVersion
+		if c.from != "" {
+			from = MustParseGameVersion(c.from)
+		}
+		if c.to != "" {
+			to = MustParseGameVersion(c.to)
+		}
+		if got := v.InPatchRange(from, to); got != c.want {
+			t.Errorf("InPatchRange(%q, %q) = %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestGameVersionJSON(t *testing.T) {
+	var decoded struct{ Patch GameVersion }
+	if err := json.Unmarshal([]byte(`{"Patch": "14.19"}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Patch != (GameVersion{Major: 14, Minor: 19}) {
+		t.Errorf("unexpected version %+v", decoded.Patch)
+	}
+
+	info := MatchInfo{GameVersion: "14.19.621.1234"}
+	if info.Patch() != MustParseGameVersion("14.19") {
+		t.Errorf("unexpected match patch %s", info.Patch())
+	}
+}